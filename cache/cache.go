@@ -0,0 +1,154 @@
+// Package cache implements a bounded cache of parsed versions.
+//
+// Comparing two version strings parses both of them, which dominates the cost
+// of a comparison. Callers that compare the same strings over and over, such
+// as dependency resolvers, can keep the parsed versions in a Cache instead.
+// Each version scheme has its own Cache, which its string-based functions use.
+// These caches start disabled, so that comparing strings neither locks nor
+// keeps versions alive unless a caller opts in:
+//
+//	maven.ParseCache().Resize(10000) // hold up to 10000 versions
+//	maven.ParseCache().Resize(0)     // disable the cache again
+//	stats := maven.ParseCache().Stats()
+//
+// A Cache evicts the least recently used version when it is full, and is safe
+// for concurrent use. A disabled Cache takes no lock.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+)
+
+// Stats are the counters of a Cache.
+type Stats struct {
+	// Hits and Misses count the calls to Get that found a value and that
+	// did not. Calls to a disabled Cache are not counted.
+	Hits, Misses uint64
+	// Evictions counts the values removed to make room for others.
+	Evictions uint64
+	// Len is the number of values held, and Capacity the most it may hold.
+	Len, Capacity int
+}
+
+// Cache is a least recently used cache of values keyed by version string.
+type Cache struct {
+	enabled   int32 // 1 if capacity > 0, read without holding mu
+	mu        sync.Mutex
+	capacity  int
+	order     *list.List // most recently used first
+	entries   map[string]*list.Element
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+type entry struct {
+	key   string
+	value interface{}
+}
+
+// New returns a Cache that holds up to capacity values. A Cache with a
+// capacity of 0 or less holds nothing.
+func New(capacity int) *Cache {
+	c := &Cache{
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+	c.setCapacity(capacity)
+	return c
+}
+
+// Get returns the value cached for key, and whether there is one.
+func (c *Cache) Get(key string) (interface{}, bool) {
+	if atomic.LoadInt32(&c.enabled) == 0 {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.order.MoveToFront(e)
+	return e.Value.(*entry).value, true
+}
+
+// Add caches value for key, evicting the least recently used value if the
+// cache is full. It does nothing if the cache is disabled.
+func (c *Cache) Add(key string, value interface{}) {
+	if atomic.LoadInt32(&c.enabled) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.capacity == 0 {
+		return
+	}
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*entry).value = value
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&entry{key, value})
+	c.evict()
+}
+
+// Resize changes the capacity of the cache, evicting the least recently used
+// values that no longer fit. A capacity of 0 or less disables the cache.
+func (c *Cache) Resize(capacity int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setCapacity(capacity)
+	c.evict()
+}
+
+// Purge removes all values from the cache and resets its counters.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+	c.hits, c.misses, c.evictions = 0, 0, 0
+}
+
+// Stats returns the counters of the cache.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Len:       c.order.Len(),
+		Capacity:  c.capacity,
+	}
+}
+
+// setCapacity sets the capacity of the cache, and whether it is enabled. c.mu
+// must be held, unless c is not shared yet.
+func (c *Cache) setCapacity(capacity int) {
+	if capacity < 0 {
+		capacity = 0
+	}
+	c.capacity = capacity
+	if capacity > 0 {
+		atomic.StoreInt32(&c.enabled, 1)
+	} else {
+		atomic.StoreInt32(&c.enabled, 0)
+	}
+}
+
+// evict removes the least recently used values until the cache fits its
+// capacity. c.mu must be held.
+func (c *Cache) evict() {
+	for c.order.Len() > c.capacity {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*entry).key)
+		c.evictions++
+	}
+}
@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	c := New(2)
+	if _, ok := c.Get("1.0"); ok {
+		t.Error("Get(1.0) on an empty cache: got a value")
+	}
+	c.Add("1.0", 10)
+	c.Add("2.0", 20)
+	if v, ok := c.Get("1.0"); !ok || v != 10 {
+		t.Errorf("Get(1.0): got %v, %v, want 10, true", v, ok)
+	}
+	// 2.0 is now the least recently used.
+	c.Add("3.0", 30)
+	if _, ok := c.Get("2.0"); ok {
+		t.Error("Get(2.0): got a value, want it evicted")
+	}
+	for _, key := range []string{"1.0", "3.0"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("Get(%s): got no value", key)
+		}
+	}
+	c.Add("3.0", 31)
+	if v, _ := c.Get("3.0"); v != 31 {
+		t.Errorf("Get(3.0) after replacing it: got %v, want 31", v)
+	}
+
+	want := Stats{Hits: 4, Misses: 2, Evictions: 1, Len: 2, Capacity: 2}
+	if got := c.Stats(); got != want {
+		t.Errorf("Stats: got %+v, want %+v", got, want)
+	}
+}
+
+func TestCacheResize(t *testing.T) {
+	c := New(3)
+	for _, key := range []string{"1", "2", "3"} {
+		c.Add(key, key)
+	}
+	c.Resize(1)
+	if _, ok := c.Get("3"); !ok {
+		t.Error("Get(3): got no value, want the most recent one kept")
+	}
+	if got := c.Stats(); got.Len != 1 || got.Evictions != 2 {
+		t.Errorf("Stats: got %+v, want Len 1 and Evictions 2", got)
+	}
+
+	misses := c.Stats().Misses
+	c.Resize(0)
+	c.Add("4", "4")
+	if _, ok := c.Get("4"); ok {
+		t.Error("Get(4) on a disabled cache: got a value")
+	}
+	if got := c.Stats(); got.Len != 0 || got.Capacity != 0 || got.Misses != misses {
+		t.Errorf("Stats: got %+v, want an empty cache and %d misses", got, misses)
+	}
+
+	c.Resize(-1)
+	if got := c.Stats().Capacity; got != 0 {
+		t.Errorf("Capacity after Resize(-1): got %d, want 0", got)
+	}
+	if got := New(-1).Stats().Capacity; got != 0 {
+		t.Errorf("Capacity of New(-1): got %d, want 0", got)
+	}
+}
+
+func TestCachePurge(t *testing.T) {
+	c := New(2)
+	c.Add("1", 1)
+	c.Get("1")
+	c.Get("2")
+	c.Purge()
+	if _, ok := c.Get("1"); ok {
+		t.Error("Get(1) after Purge: got a value")
+	}
+	want := Stats{Misses: 1, Capacity: 2}
+	if got := c.Stats(); got != want {
+		t.Errorf("Stats: got %+v, want %+v", got, want)
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	c := New(50)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := strconv.Itoa((g + i) % 100)
+				if v, ok := c.Get(key); ok && v != key {
+					t.Errorf("Get(%s): got %v", key, v)
+				}
+				c.Add(key, key)
+				if i%250 == 0 {
+					c.Resize(25 + i%50)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	s := c.Stats()
+	if s.Hits+s.Misses != 8000 {
+		t.Errorf("got %d hits and %d misses, want 8000 lookups", s.Hits, s.Misses)
+	}
+	if s.Len > s.Capacity {
+		t.Errorf("got %d values, want at most %d", s.Len, s.Capacity)
+	}
+}
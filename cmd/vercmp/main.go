@@ -0,0 +1,89 @@
+// Command vercmp compares version strings.
+//
+// Usage:
+//
+//	vercmp explain [-scheme maven|semver] [-profile 3.0|3.6|3.9] a b
+//
+// The explain subcommand prints the parsed form of a and b, how they compare,
+// and the item that decided the comparison.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/wfscheper/vercmp"
+	"github.com/wfscheper/vercmp/maven"
+	"github.com/wfscheper/vercmp/semver"
+)
+
+const usage = "usage: vercmp explain [-scheme maven|semver] [-profile 3.0|3.6|3.9] a b\n"
+
+var profiles = map[string]maven.Profile{
+	"3.0": maven.Maven30,
+	"3.6": maven.Maven36,
+	"3.9": maven.Maven39,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	switch args[0] {
+	case "explain":
+		return explain(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	}
+	fmt.Fprintf(stderr, "vercmp: unknown command %q\n%s", args[0], usage)
+	return 2
+}
+
+func explain(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("explain", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+	scheme := fs.String("scheme", vercmp.Maven, "version `scheme`: maven or semver")
+	profile := fs.String("profile", "3.6", "Maven `release` whose comparison rules to follow")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return 2
+	}
+	a, b := fs.Arg(0), fs.Arg(1)
+
+	switch *scheme {
+	case vercmp.Maven:
+		p, ok := profiles[*profile]
+		if !ok {
+			fmt.Fprintf(stderr, "vercmp: unknown maven profile %q\n", *profile)
+			return 2
+		}
+		fmt.Fprint(stdout, p.Comparator().Explain(a, b))
+	case vercmp.SemVer:
+		e, err := semver.Explain(a, b)
+		if err != nil {
+			fmt.Fprintf(stderr, "vercmp: %v\n", err)
+			return 1
+		}
+		fmt.Fprint(stdout, e)
+	default:
+		fmt.Fprintf(stderr, "vercmp: explain does not support scheme %q\n", *scheme)
+		return 2
+	}
+	return 0
+}
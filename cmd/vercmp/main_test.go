@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		status int
+		stdout string
+		stderr string
+	}{
+		{
+			name:   "maven",
+			args:   []string{"explain", "1.0-rc1", "1.0.cr1"},
+			stdout: "1.0-rc1 < 1.0.cr1\nat item 1: list",
+		},
+		{
+			name:   "maven profile",
+			args:   []string{"explain", "-profile", "3.9", "1-0.1", "1"},
+			stdout: "1-0.1 > 1\n",
+		},
+		{
+			name:   "semver",
+			args:   []string{"explain", "-scheme", "semver", "1.2.3.rc1", "1.2.3"},
+			stdout: "at pre-release type: ",
+		},
+		{
+			name:   "help",
+			args:   []string{"help"},
+			stdout: "usage: vercmp explain",
+		},
+		{
+			name:   "no command",
+			status: 2,
+			stderr: "usage: vercmp explain",
+		},
+		{
+			name:   "unknown command",
+			args:   []string{"frobnicate"},
+			status: 2,
+			stderr: `unknown command "frobnicate"`,
+		},
+		{
+			name:   "missing version",
+			args:   []string{"explain", "1.0"},
+			status: 2,
+			stderr: "usage: vercmp explain",
+		},
+		{
+			name:   "unknown flag",
+			args:   []string{"explain", "-x", "1", "2"},
+			status: 2,
+			stderr: "flag provided but not defined",
+		},
+		{
+			name:   "unknown profile",
+			args:   []string{"explain", "-profile", "2.0", "1", "2"},
+			status: 2,
+			stderr: `unknown maven profile "2.0"`,
+		},
+		{
+			name:   "unknown scheme",
+			args:   []string{"explain", "-scheme", "npm", "1", "2"},
+			status: 2,
+			stderr: `does not support scheme "npm"`,
+		},
+		{
+			name:   "invalid semver",
+			args:   []string{"explain", "-scheme", "semver", "1.2", "1.2.3"},
+			status: 1,
+			stderr: "Invalid semantic version: 1.2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if got := run(tt.args, &stdout, &stderr); got != tt.status {
+				t.Errorf("got status %d, want %d", got, tt.status)
+			}
+			if !strings.Contains(stdout.String(), tt.stdout) {
+				t.Errorf("stdout: got %q, want it to contain %q", stdout.String(), tt.stdout)
+			}
+			if !strings.Contains(stderr.String(), tt.stderr) {
+				t.Errorf("stderr: got %q, want it to contain %q", stderr.String(), tt.stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+// Package constraint implements a version constraint language that works with
+// any version scheme registered in the vercmp package.
+//
+// A constraint is made of comparisons joined by "&&" and "||", optionally
+// grouped with parentheses:
+//
+//	>=1.2.0 && <2.0.0 || ==2.1.0
+//	(>=1.0 && !=1.3) || >=2.0
+//
+// The comparison operators are ==, !=, <, <=, > and >=. A version without an
+// operator must match exactly, so "1.2.0" is the same as "==1.2.0". "&&" binds
+// more tightly than "||".
+//
+// A constraint is parsed once into a tree of Nodes, and can then be checked
+// against versions of any scheme.
+package constraint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wfscheper/vercmp"
+)
+
+// Op is a comparison operator.
+type Op int
+
+// Comparison operators.
+const (
+	EQ Op = iota
+	NE
+	LT
+	LE
+	GT
+	GE
+)
+
+var opNames = [...]string{"==", "!=", "<", "<=", ">", ">="}
+
+func (op Op) String() string {
+	if op < EQ || op > GE {
+		return "?"
+	}
+	return opNames[op]
+}
+
+// matches reports whether the result c of comparing a version with an operand
+// satisfies op.
+func (op Op) matches(c int) bool {
+	switch op {
+	case EQ:
+		return c == 0
+	case NE:
+		return c != 0
+	case LT:
+		return c < 0
+	case LE:
+		return c <= 0
+	case GT:
+		return c > 0
+	case GE:
+		return c >= 0
+	}
+	return false
+}
+
+// Node is a node in the tree of a parsed constraint. It is one of
+// *Comparison, *And or *Or.
+type Node interface {
+	String() string
+	check(cmp vercmp.CompareFunc, v string) (bool, error)
+}
+
+// Comparison is satisfied by versions that compare to Version as Op says.
+type Comparison struct {
+	Op      Op
+	Version string
+}
+
+func (n *Comparison) String() string {
+	return n.Op.String() + n.Version
+}
+
+func (n *Comparison) check(cmp vercmp.CompareFunc, v string) (bool, error) {
+	c, err := cmp(v, n.Version)
+	if err != nil {
+		return false, err
+	}
+	return n.Op.matches(c), nil
+}
+
+// And is satisfied by versions that satisfy both X and Y.
+type And struct {
+	X, Y Node
+}
+
+func (n *And) String() string {
+	return group(n.X) + " && " + group(n.Y)
+}
+
+func (n *And) check(cmp vercmp.CompareFunc, v string) (bool, error) {
+	ok, err := n.X.check(cmp, v)
+	if !ok || err != nil {
+		return false, err
+	}
+	return n.Y.check(cmp, v)
+}
+
+// Or is satisfied by versions that satisfy either X or Y.
+type Or struct {
+	X, Y Node
+}
+
+func (n *Or) String() string {
+	return n.X.String() + " || " + n.Y.String()
+}
+
+func (n *Or) check(cmp vercmp.CompareFunc, v string) (bool, error) {
+	ok, err := n.X.check(cmp, v)
+	if ok || err != nil {
+		return ok, err
+	}
+	return n.Y.check(cmp, v)
+}
+
+// group returns the string of n, in parentheses if it is an *Or.
+func group(n Node) string {
+	if _, ok := n.(*Or); ok {
+		return "(" + n.String() + ")"
+	}
+	return n.String()
+}
+
+// Constraint is a parsed version constraint.
+type Constraint struct {
+	root Node
+}
+
+// Parse parses a constraint expression.
+func Parse(expr string) (*Constraint, error) {
+	p := &parser{lexer: lexer{src: expr}}
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &Constraint{root}, nil
+}
+
+// MustParse is like Parse but panics if expr cannot be parsed.
+func MustParse(expr string) *Constraint {
+	c, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// New returns a Constraint with root as its tree.
+func New(root Node) *Constraint {
+	return &Constraint{root}
+}
+
+// Root returns the root of the constraint's tree.
+func (c *Constraint) Root() Node {
+	return c.root
+}
+
+// String returns the constraint in normalized form.
+func (c *Constraint) String() string {
+	return c.root.String()
+}
+
+// Check reports whether version satisfies the constraint, comparing versions
+// with the scheme registered under name in the vercmp package. It returns an
+// error if the scheme is unknown or a comparison fails.
+func (c *Constraint) Check(name, version string) (bool, error) {
+	cmp, ok := vercmp.Lookup(name)
+	if !ok {
+		return false, fmt.Errorf("constraint: unknown scheme %q", name)
+	}
+	return c.CheckFunc(cmp, version)
+}
+
+// CheckFunc reports whether version satisfies the constraint, comparing
+// versions with cmp.
+func (c *Constraint) CheckFunc(cmp vercmp.CompareFunc, version string) (bool, error) {
+	return c.root.check(cmp, strings.TrimSpace(version))
+}
@@ -0,0 +1,165 @@
+package constraint
+
+import (
+	"testing"
+
+	"github.com/wfscheper/vercmp"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		expr, want string
+	}{
+		{"1.2.0", "==1.2.0"},
+		{"=1.2.0", "==1.2.0"},
+		{" >= 1.2.0 ", ">=1.2.0"},
+		{">=1.2.0 && <2.0.0", ">=1.2.0 && <2.0.0"},
+		{">=1.2.0 && <2.0.0 || ==2.1.0", ">=1.2.0 && <2.0.0 || ==2.1.0"},
+		{"==2.1.0 || >=1.2.0 && <2.0.0", "==2.1.0 || >=1.2.0 && <2.0.0"},
+		{">=1.0 && (<1.5 || >1.7)", ">=1.0 && (<1.5 || >1.7)"},
+		{"((1.0))", "==1.0"},
+		{"(>=1.0 && !=1.3) || >=2.0", ">=1.0 && !=1.3 || >=2.0"},
+		{"<1.0-SNAPSHOT||>1.0-rc1", "<1.0-SNAPSHOT || >1.0-rc1"},
+		{">1 && <3 && !=2", ">1 && <3 && !=2"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			c, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got := c.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTree(t *testing.T) {
+	c := MustParse(">=1.2.0 && <2.0.0 || ==2.1.0")
+	or, ok := c.Root().(*Or)
+	if !ok {
+		t.Fatalf("got %T, want *Or", c.Root())
+	}
+	and, ok := or.X.(*And)
+	if !ok {
+		t.Fatalf("got %T, want *And", or.X)
+	}
+	if got := *and.X.(*Comparison); got != (Comparison{GE, "1.2.0"}) {
+		t.Errorf("got %v, want >=1.2.0", got)
+	}
+	if got := *and.Y.(*Comparison); got != (Comparison{LT, "2.0.0"}) {
+		t.Errorf("got %v, want <2.0.0", got)
+	}
+	if got := *or.Y.(*Comparison); got != (Comparison{EQ, "2.1.0"}) {
+		t.Errorf("got %v, want ==2.1.0", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"   ",
+		">=",
+		">= && 1.0",
+		"1.0 &&",
+		"1.0 ||",
+		"1.0 & 2.0",
+		"1.0 | 2.0",
+		"(1.0",
+		"1.0)",
+		"()",
+		"1.0 2.0",
+		"! 1.0",
+		">=<1.0",
+	}
+
+	t.Parallel()
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			if c, err := Parse(expr); err == nil {
+				t.Errorf("got %v, want error", c)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		expr, scheme, version string
+		want                  bool
+	}{
+		{">=1.2.0 && <2.0.0 || ==2.1.0", vercmp.SemVer, "1.2.0", true},
+		{">=1.2.0 && <2.0.0 || ==2.1.0", vercmp.SemVer, "1.9.9", true},
+		{">=1.2.0 && <2.0.0 || ==2.1.0", vercmp.SemVer, "2.0.0", false},
+		{">=1.2.0 && <2.0.0 || ==2.1.0", vercmp.SemVer, "2.1.0", true},
+		{">=1.2.0 && <2.0.0 || ==2.1.0", vercmp.SemVer, "1.2.0.rc1", false},
+		{">=1.2.0 && <2.0.0 || ==2.1.0", vercmp.Maven, "1.2", true},
+		{">=1.2.0 && <2.0.0 || ==2.1.0", vercmp.Maven, "2.0-SNAPSHOT", true},
+		{">=1.2.0 && <2.0.0 || ==2.1.0", vercmp.Maven, "2.1", true},
+		{">=1.2.0 && <2.0.0 || ==2.1.0", vercmp.Maven, "1.2-rc1", false},
+		{"!=1.3", vercmp.Maven, "1.3.0", false},
+		{"!=1.3", vercmp.Maven, "1.3.1", true},
+		{">1.0 && !=1.3 || >=2.0", vercmp.Maven, "1.3", false},
+		{">1.0 && (!=1.3 || >=2.0)", vercmp.Maven, "1.2", true},
+		{"<=1.0", vercmp.Maven, "1.0-sp", false},
+		{">1.0", vercmp.Maven, "1.0-sp", true},
+		{"<1.0.0", vercmp.SemVer, " 0.9.0 ", true},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.scheme+" "+tt.version+" in "+tt.expr, func(t *testing.T) {
+			got, err := MustParse(tt.expr).Check(tt.scheme, tt.version)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckErrors(t *testing.T) {
+	tests := []struct {
+		expr, scheme, version string
+	}{
+		{">=1.0.0", "unknown", "1.0.0"},
+		{">=1.0.0", vercmp.SemVer, "1.0"},
+		{">=1.0", vercmp.SemVer, "1.0.0"},
+		{"<1.0.0 || >=1.0", vercmp.SemVer, "1.0.0"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.scheme+" "+tt.version+" in "+tt.expr, func(t *testing.T) {
+			if _, err := MustParse(tt.expr).Check(tt.scheme, tt.version); err == nil {
+				t.Error("got nil, want error")
+			}
+		})
+	}
+}
+
+func TestCheckFunc(t *testing.T) {
+	byLength := func(a, b string) (int, error) {
+		return len(a) - len(b), nil
+	}
+	c := New(&And{&Comparison{GT, "x"}, &Comparison{LT, "xxxx"}})
+	for v, want := range map[string]bool{"": false, "xx": true, "xxx": true, "xxxx": false} {
+		if got, _ := c.CheckFunc(byLength, v); got != want {
+			t.Errorf("CheckFunc(%q): got %v, want %v", v, got, want)
+		}
+	}
+}
+
+func TestMustParsePanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("got no panic, want panic")
+		}
+	}()
+	MustParse("&&")
+}
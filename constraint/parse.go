@@ -0,0 +1,180 @@
+package constraint
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokAnd
+	tokOr
+	tokLParen
+	tokRParen
+	tokOp
+	tokVersion
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+	op   Op
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of constraint"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// operators is ordered so that two-character operators are tried first.
+var operators = []struct {
+	text string
+	op   Op
+}{
+	{"==", EQ}, {"!=", NE}, {"<=", LE}, {">=", GE}, {"<", LT}, {">", GT}, {"=", EQ},
+}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+func (l *lexer) next() token {
+	for l.pos < len(l.src) && unicode.IsSpace(rune(l.src[l.pos])) {
+		l.pos++
+	}
+	start := l.pos
+	rest := l.src[l.pos:]
+	switch {
+	case rest == "":
+		return token{kind: tokEOF, pos: start}
+	case strings.HasPrefix(rest, "&&"):
+		l.pos += 2
+		return token{kind: tokAnd, text: "&&", pos: start}
+	case strings.HasPrefix(rest, "||"):
+		l.pos += 2
+		return token{kind: tokOr, text: "||", pos: start}
+	case rest[0] == '(':
+		l.pos++
+		return token{kind: tokLParen, text: "(", pos: start}
+	case rest[0] == ')':
+		l.pos++
+		return token{kind: tokRParen, text: ")", pos: start}
+	}
+	for _, o := range operators {
+		if strings.HasPrefix(rest, o.text) {
+			l.pos += len(o.text)
+			return token{kind: tokOp, text: o.text, pos: start, op: o.op}
+		}
+	}
+	for l.pos < len(l.src) && !strings.ContainsRune(" \t\r\n()&|<>=!", rune(l.src[l.pos])) {
+		l.pos++
+	}
+	if l.pos == start {
+		// a lone '&', '|' or '!'
+		l.pos++
+	}
+	return token{kind: tokVersion, text: l.src[start:l.pos], pos: start}
+}
+
+// parser is a recursive descent parser for the grammar
+//
+//	or         = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "(" or ")" | comparison
+//	comparison = [ op ] version
+type parser struct {
+	lexer lexer
+	tok   token
+}
+
+func (p *parser) parse() (Node, error) {
+	p.advance()
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %v", p.tok)
+	}
+	return n, nil
+}
+
+func (p *parser) advance() {
+	p.tok = p.lexer.next()
+}
+
+func (p *parser) parseOr() (Node, error) {
+	x, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokOr {
+		p.advance()
+		y, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		x = &Or{x, y}
+	}
+	return x, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokAnd {
+		p.advance()
+		y, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		x = &And{x, y}
+	}
+	return x, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if p.tok.kind == tokLParen {
+		p.advance()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			return nil, p.errorf("expected \")\", found %v", p.tok)
+		}
+		p.advance()
+		return n, nil
+	}
+
+	op := EQ
+	if p.tok.kind == tokOp {
+		op = p.tok.op
+		p.advance()
+	}
+	if p.tok.kind != tokVersion || !isVersion(p.tok.text) {
+		return nil, p.errorf("expected version, found %v", p.tok)
+	}
+	n := &Comparison{op, p.tok.text}
+	p.advance()
+	return n, nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("constraint: offset %d: %s", p.tok.pos, fmt.Sprintf(format, args...))
+}
+
+// isVersion reports whether s can be a version operand, rather than a stray
+// '&', '|' or '!'.
+func isVersion(s string) bool {
+	return s != "&" && s != "|" && s != "!"
+}
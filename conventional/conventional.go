@@ -0,0 +1,114 @@
+// Package conventional computes the next semantic version from a history of
+// commit messages written in the Conventional Commits format.
+//
+// See https://www.conventionalcommits.org/en/v1.0.0/ for the format. A commit
+// of type "feat" adds a feature, a commit of type "fix" fixes a bug, and any
+// commit marked with "!" or carrying a "BREAKING CHANGE" footer introduces a
+// breaking change. All other commit types leave the version alone.
+package conventional
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/wfscheper/vercmp/semver"
+)
+
+// Bump describes which part of a version a set of commits requires to change.
+type Bump int
+
+// Bumps in increasing order of significance.
+const (
+	None Bump = iota
+	Patch
+	Minor
+	Major
+)
+
+var bumpNames = [...]string{"none", "patch", "minor", "major"}
+
+var (
+	headerRe = regexp.MustCompile(`^(\w+)(?:\([^()\r\n]*\))?(!)?: \S`)
+	footerRe = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: `)
+)
+
+func (b Bump) String() string {
+	if b < None || b > Major {
+		return "unknown"
+	}
+	return bumpNames[b]
+}
+
+// ParseBump returns the bump required by a single commit message. Messages
+// that do not follow the Conventional Commits format require no bump.
+func ParseBump(message string) Bump {
+	message = strings.TrimSpace(message)
+	m := headerRe.FindStringSubmatch(message)
+	if m == nil {
+		return None
+	}
+	if m[2] == "!" || footerRe.MatchString(message) {
+		return Major
+	}
+	switch strings.ToLower(m[1]) {
+	case "feat":
+		return Minor
+	case "fix":
+		return Patch
+	default:
+		return None
+	}
+}
+
+// MaxBump returns the most significant bump required by any of messages.
+func MaxBump(messages []string) Bump {
+	bump := None
+	for _, m := range messages {
+		if b := ParseBump(m); b > bump {
+			bump = b
+			if bump == Major {
+				break
+			}
+		}
+	}
+	return bump
+}
+
+// Apply returns v bumped by b. Pre-release and dev parts are always dropped.
+// If v is a pre-release, the bump releases it when it already targets the
+// requested level, so 1.2.0.rc1 bumped by Minor becomes 1.2.0, not 1.3.0.
+// Bumping by None returns v unchanged.
+func (b Bump) Apply(v semver.Version) semver.Version {
+	if b == None {
+		return v
+	}
+	pre := v.PreReleaseType != "" || v.DevCount != 0
+	next := semver.Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	switch b {
+	case Major:
+		if !pre || v.Minor != 0 || v.Patch != 0 {
+			next = semver.Version{Major: v.Major + 1}
+		}
+	case Minor:
+		if !pre || v.Patch != 0 {
+			next = semver.Version{Major: v.Major, Minor: v.Minor + 1}
+		}
+	case Patch:
+		if !pre {
+			next.Patch++
+		}
+	}
+	return next
+}
+
+// Next returns the version that follows current once the commits described by
+// messages are released. If preMajor is true and current is a 0.y.z version,
+// breaking changes bump the minor version instead of the major version, so
+// that a project can stay in initial development.
+func Next(current semver.Version, messages []string, preMajor bool) semver.Version {
+	bump := MaxBump(messages)
+	if preMajor && current.Major == 0 && bump == Major {
+		bump = Minor
+	}
+	return bump.Apply(current)
+}
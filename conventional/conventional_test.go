@@ -0,0 +1,128 @@
+package conventional
+
+import (
+	"testing"
+
+	"github.com/wfscheper/vercmp/semver"
+)
+
+func TestParseBump(t *testing.T) {
+	tests := []struct {
+		message string
+		want    Bump
+	}{
+		{"feat: add a widget", Minor},
+		{"Feat: add a widget", Minor},
+		{"feat(parser): add a widget", Minor},
+		{"fix: stop crashing", Patch},
+		{"fix(parser): stop crashing", Patch},
+		{"docs: fix a typo", None},
+		{"chore(deps): update", None},
+		{"feat!: drop the old api", Major},
+		{"fix(api)!: drop the old api", Major},
+		{"refactor!: rewrite everything", Major},
+		{"feat: new api\n\nBREAKING CHANGE: the old api is gone", Major},
+		{"fix: new api\n\nBREAKING-CHANGE: the old api is gone", Major},
+		{"chore: mention BREAKING CHANGE: in passing", None},
+		{"feat:missing space", None},
+		{"feat: ", None},
+		{"add a widget", None},
+		{"", None},
+		{"  feat: leading whitespace", Minor},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			if got := ParseBump(tt.message); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxBump(t *testing.T) {
+	tests := []struct {
+		title    string
+		messages []string
+		want     Bump
+	}{
+		{"No messages", nil, None},
+		{"Only chores", []string{"chore: a", "docs: b"}, None},
+		{"Fix", []string{"chore: a", "fix: b"}, Patch},
+		{"Fix and feat", []string{"fix: a", "feat: b", "fix: c"}, Minor},
+		{"Breaking", []string{"fix: a", "feat!: b", "feat: c"}, Major},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := MaxBump(tt.messages); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		current  string
+		messages []string
+		preMajor bool
+		want     string
+	}{
+		{"1.2.3", nil, false, "1.2.3"},
+		{"1.2.3", []string{"docs: a"}, false, "1.2.3"},
+		{"1.2.3", []string{"fix: a"}, false, "1.2.4"},
+		{"1.2.3", []string{"feat: a"}, false, "1.3.0"},
+		{"1.2.3", []string{"feat!: a"}, false, "2.0.0"},
+		{"1.2.3", []string{"feat!: a"}, true, "2.0.0"},
+		{"0.2.3", []string{"feat!: a"}, false, "1.0.0"},
+		{"0.2.3", []string{"feat!: a"}, true, "0.3.0"},
+		{"0.2.3", []string{"feat: a"}, true, "0.3.0"},
+		{"0.2.3", []string{"fix: a"}, true, "0.2.4"},
+		// pre-releases are released when they already target the bump
+		{"1.2.3.rc1", []string{"fix: a"}, false, "1.2.3"},
+		{"1.2.3.dev4", []string{"fix: a"}, false, "1.2.3"},
+		{"1.2.0.b2", []string{"feat: a"}, false, "1.2.0"},
+		{"1.2.3.a1", []string{"feat: a"}, false, "1.3.0"},
+		{"2.0.0.a1.dev3", []string{"feat!: a"}, false, "2.0.0"},
+		{"2.1.0.a1", []string{"feat!: a"}, false, "3.0.0"},
+		{"1.2.3.rc1", []string{"chore: a"}, false, "1.2.3.rc1"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.current+" -> "+tt.want, func(t *testing.T) {
+			current, err := semver.New(tt.current)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got := Next(*current, tt.messages, tt.preMajor); got.String() != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBumpString(t *testing.T) {
+	tests := []struct {
+		b    Bump
+		want string
+	}{
+		{None, "none"},
+		{Patch, "patch"},
+		{Minor, "minor"},
+		{Major, "major"},
+		{Bump(42), "unknown"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.b.String(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
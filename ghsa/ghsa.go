@@ -0,0 +1,154 @@
+// Package ghsa evaluates the vulnerable version ranges of GitHub Security
+// Advisories.
+//
+// A vulnerable_version_range is a comma separated list of comparisons that
+// must all hold, such as ">= 1.0.0, < 1.2.5" or "= 2.0.0". Versions are
+// compared with the scheme that Ecosystems maps the advisory's ecosystem to,
+// so advisories exported from the GitHub REST API can be checked offline.
+package ghsa
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/wfscheper/vercmp"
+	"github.com/wfscheper/vercmp/constraint"
+)
+
+// Ecosystems maps lower case GHSA ecosystem names to the vercmp schemes that
+// compare their versions. Callers may add entries before evaluating
+// advisories.
+var Ecosystems = map[string]string{
+	"maven": vercmp.Maven,
+	"go":    vercmp.SemVer2,
+	"npm":   vercmp.SemVer2,
+	"rust":  vercmp.SemVer2,
+}
+
+// Advisory is a GitHub Security Advisory as returned by the REST API.
+type Advisory struct {
+	GHSAID          string          `json:"ghsa_id"`
+	CVEID           string          `json:"cve_id,omitempty"`
+	Summary         string          `json:"summary,omitempty"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+}
+
+// Vulnerability describes the affected versions of a single package.
+type Vulnerability struct {
+	Package                Package `json:"package"`
+	VulnerableVersionRange string  `json:"vulnerable_version_range"`
+	FirstPatchedVersion    string  `json:"first_patched_version,omitempty"`
+}
+
+// Package identifies a package within an ecosystem.
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+}
+
+// Parse parses a single advisory.
+func Parse(data []byte) (*Advisory, error) {
+	a := new(Advisory)
+	if err := json.Unmarshal(data, a); err != nil {
+		return nil, fmt.Errorf("ghsa: %v", err)
+	}
+	return a, nil
+}
+
+// ParseList parses a JSON array of advisories.
+func ParseList(data []byte) ([]Advisory, error) {
+	var advisories []Advisory
+	if err := json.Unmarshal(data, &advisories); err != nil {
+		return nil, fmt.Errorf("ghsa: %v", err)
+	}
+	return advisories, nil
+}
+
+// Load reads the advisories in the file at path, which may hold either a
+// single advisory or an array of them.
+func Load(path string) ([]Advisory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+		advisories, err := ParseList(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		return advisories, nil
+	}
+	a, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return []Advisory{*a}, nil
+}
+
+// Affects reports whether version of the package name in ecosystem is
+// affected by a. Ecosystems are matched without regard to case.
+func (a *Advisory) Affects(ecosystem, name, version string) (bool, error) {
+	for _, v := range a.Vulnerabilities {
+		if !strings.EqualFold(v.Package.Ecosystem, ecosystem) || v.Package.Name != name {
+			continue
+		}
+		ok, err := v.Affects(version)
+		if ok || err != nil {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// Affects reports whether version falls within v's vulnerable version range.
+func (v *Vulnerability) Affects(version string) (bool, error) {
+	name, ok := Ecosystems[strings.ToLower(v.Package.Ecosystem)]
+	if !ok {
+		return false, fmt.Errorf("ghsa: unsupported ecosystem %q", v.Package.Ecosystem)
+	}
+	c, err := ParseRange(v.VulnerableVersionRange)
+	if err != nil {
+		return false, err
+	}
+	return c.Check(name, version)
+}
+
+// ParseRange parses a vulnerable_version_range into a constraint.
+func ParseRange(s string) (*constraint.Constraint, error) {
+	var root constraint.Node
+	for _, part := range strings.Split(s, ",") {
+		n, err := parseComparison(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("ghsa: invalid version range %q: %v", s, err)
+		}
+		if root == nil {
+			root = n
+		} else {
+			root = &constraint.And{X: root, Y: n}
+		}
+	}
+	return constraint.New(root), nil
+}
+
+// operators is ordered so that two-character operators are tried first.
+var operators = []struct {
+	text string
+	op   constraint.Op
+}{
+	{"<=", constraint.LE}, {">=", constraint.GE}, {"<", constraint.LT}, {">", constraint.GT}, {"=", constraint.EQ},
+}
+
+func parseComparison(s string) (*constraint.Comparison, error) {
+	for _, o := range operators {
+		if strings.HasPrefix(s, o.text) {
+			v := strings.TrimSpace(s[len(o.text):])
+			if v == "" || strings.ContainsAny(v, " \t") {
+				return nil, fmt.Errorf("invalid version in %q", s)
+			}
+			return &constraint.Comparison{Op: o.op, Version: v}, nil
+		}
+	}
+	return nil, fmt.Errorf("missing operator in %q", s)
+}
@@ -0,0 +1,166 @@
+package ghsa
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/wfscheper/vercmp/constraint"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"= 2.0.0", "==2.0.0"},
+		{"< 1.2.5", "<1.2.5"},
+		{"<= 1.2.5", "<=1.2.5"},
+		{"> 1.0.0", ">1.0.0"},
+		{">= 1.0.0, < 1.2.5", ">=1.0.0 && <1.2.5"},
+		{">=1.0.0,<1.2.5", ">=1.0.0 && <1.2.5"},
+		{">= 2.0-beta9, < 2.12.2", ">=2.0-beta9 && <2.12.2"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			c, err := ParseRange(tt.in)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got := c.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRangeStructure(t *testing.T) {
+	c, err := ParseRange(">= 1.0.0, < 1.2.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	and, ok := c.Root().(*constraint.And)
+	if !ok {
+		t.Fatalf("got %T, want *constraint.And", c.Root())
+	}
+	if x, ok := and.X.(*constraint.Comparison); !ok || x.Op != constraint.GE || x.Version != "1.0.0" {
+		t.Errorf("got %v, want >=1.0.0", and.X)
+	}
+	if y, ok := and.Y.(*constraint.Comparison); !ok || y.Op != constraint.LT || y.Version != "1.2.5" {
+		t.Errorf("got %v, want <1.2.5", and.Y)
+	}
+}
+
+func TestParseRangeErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"1.0.0",
+		">= ",
+		">= 1.0.0,",
+		">= 1.0.0 < 1.2.5",
+		"~> 1.0.0",
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			if _, err := ParseRange(tt); err == nil {
+				t.Errorf("got nil, want error")
+			}
+		})
+	}
+}
+
+func TestAffects(t *testing.T) {
+	tests := []struct {
+		file, ecosystem, name, version string
+		want                           bool
+	}{
+		{"GHSA-test-maven", "maven", "org.example:example-core", "2.0-alpha1", false},
+		{"GHSA-test-maven", "maven", "org.example:example-core", "2.0-beta9", true},
+		{"GHSA-test-maven", "MAVEN", "org.example:example-core", "2.0-rc2", true},
+		{"GHSA-test-maven", "Maven", "org.example:example-core", "2.12.1", true},
+		{"GHSA-test-maven", "maven", "org.example:example-core", "2.12.2", false},
+		{"GHSA-test-maven", "maven", "org.example:example-core", "2.13.0", true},
+		{"GHSA-test-maven", "maven", "org.example:example-core", "2.15.0-SNAPSHOT", true},
+		{"GHSA-test-maven", "maven", "org.example:example-core", "2.15.0", false},
+		{"GHSA-test-maven", "maven", "org.example:example-api", "1.4", true},
+		{"GHSA-test-maven", "maven", "org.example:example-api", "1.4.1", false},
+		{"GHSA-test-maven", "maven", "org.example:other", "2.13.0", false},
+		{"GHSA-test-maven", "npm", "org.example:example-core", "2.13.0", false},
+		{"GHSA-test-list", "npm", "example", "1.4.1", true},
+		{"GHSA-test-list", "npm", "example", "1.4.2", false},
+		{"GHSA-test-list", "go", "example.com/server", "2.0.0", false},
+		{"GHSA-test-list", "go", "example.com/server", "2.3.1", true},
+		{"GHSA-test-list", "go", "example.com/server", "2.3.2", false},
+		{"GHSA-real-semver2", "npm", "semver", "7.5.1", true},
+		{"GHSA-real-semver2", "npm", "semver", "7.5.2-rc.1", true},
+		{"GHSA-real-semver2", "npm", "semver", "7.5.2", false},
+		{"GHSA-real-semver2", "npm", "semver", "6.3.0", true},
+		{"GHSA-real-semver2", "npm", "semver", "6.3.1", false},
+		{"GHSA-real-semver2", "npm", "semver", "5.7.2-beta.10", true},
+		{"GHSA-real-semver2", "npm", "semver", "5.7.2", false},
+		{"GHSA-real-semver2", "go", "golang.org/x/net", "v0.16.0", true},
+		{"GHSA-real-semver2", "go", "golang.org/x/net", "0.0.0-20230905200255-921286631fa9", true},
+		{"GHSA-real-semver2", "go", "golang.org/x/net", "v0.17.0", false},
+		{"GHSA-real-semver2", "go", "golang.org/x/net", "0.17.1-0.20231010000000-abcdefabcdef", false},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.file+" "+tt.name+" "+tt.version, func(t *testing.T) {
+			advisories, err := Load(filepath.Join("testdata", tt.file+".json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got bool
+			for _, a := range advisories {
+				ok, err := a.Affects(tt.ecosystem, tt.name, tt.version)
+				if err != nil {
+					t.Fatalf("got %v, want nil", err)
+				}
+				got = got || ok
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAffectsErrors(t *testing.T) {
+	advisories, err := Load(filepath.Join("testdata", "GHSA-test-list.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := advisories[1].Affects("pip", "example", "0.9"); err == nil {
+		t.Error("unsupported ecosystem: got nil, want error")
+	}
+	if _, err := advisories[0].Affects("npm", "example", "1.4"); err == nil {
+		t.Error("invalid semver: got nil, want error")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	advisories, err := Load(filepath.Join("testdata", "GHSA-test-maven.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(advisories) != 1 {
+		t.Fatalf("got %d advisories, want 1", len(advisories))
+	}
+	a := advisories[0]
+	if a.GHSAID != "GHSA-test-mvn1-0001" || a.CVEID != "CVE-0000-0001" {
+		t.Errorf("got %q %q, want GHSA-test-mvn1-0001 CVE-0000-0001", a.GHSAID, a.CVEID)
+	}
+	if got := a.Vulnerabilities[0].FirstPatchedVersion; got != "2.12.2" {
+		t.Errorf("got %q, want 2.12.2", got)
+	}
+
+	if _, err := Load(filepath.Join("testdata", "missing.json")); err == nil {
+		t.Error("missing file: got nil, want error")
+	}
+	if _, err := Parse([]byte("{")); err == nil {
+		t.Error("invalid json: got nil, want error")
+	}
+}
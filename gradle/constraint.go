@@ -0,0 +1,160 @@
+package gradle
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Constraint is a Gradle rich version constraint. Strictly and Require hold
+// version notations understood by ParseSelector, Prefer holds a single
+// version, and each of Reject holds a version notation.
+type Constraint struct {
+	Strictly string
+	Require  string
+	Prefer   string
+	Reject   []string
+}
+
+// ParseConstraint parses the string notation of a rich version constraint. A
+// plain notation is a required version, and "a!!b" requires strictly a and
+// prefers b.
+func ParseConstraint(s string) (*Constraint, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("invalid version constraint %q", s)
+	}
+	c := &Constraint{Require: s}
+	if i := strings.Index(s, "!!"); i >= 0 {
+		c = &Constraint{
+			Strictly: strings.TrimSpace(s[:i]),
+			Prefer:   strings.TrimSpace(s[i+2:]),
+		}
+		if c.Strictly == "" {
+			return nil, fmt.Errorf("invalid version constraint %q: empty strict version", s)
+		}
+	}
+	if _, err := c.compile(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// compiled holds the parsed selectors of a Constraint.
+type compiled struct {
+	primary Selector
+	strict  bool
+	prefer  *Version
+	rejects []Selector
+}
+
+func (c *Constraint) compile() (*compiled, error) {
+	r := &compiled{}
+	var err error
+	switch {
+	case c.Strictly != "":
+		r.strict = true
+		r.primary, err = ParseSelector(c.Strictly)
+	case c.Require != "":
+		r.primary, err = ParseSelector(c.Require)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if c.Prefer != "" {
+		r.prefer = New(c.Prefer)
+	}
+	for _, reject := range c.Reject {
+		s, err := ParseSelector(reject)
+		if err != nil {
+			return nil, err
+		}
+		r.rejects = append(r.rejects, s)
+	}
+	return r, nil
+}
+
+func (r *compiled) accepts(v string) bool {
+	for _, reject := range r.rejects {
+		if reject.Accepts(v) {
+			return false
+		}
+	}
+	switch {
+	case r.primary == nil:
+		return true
+	case r.strict || r.primary.Dynamic():
+		return r.primary.Accepts(v)
+	}
+	// A required version may be upgraded by conflict resolution.
+	return Vercmp(v, r.primary.String()) >= 0
+}
+
+// Accepts reports whether v satisfies c, that is whether v is not rejected and
+// is strictly the version or within the range that c demands. A required,
+// non-dynamic version also accepts any newer version, since Gradle's conflict
+// resolution may upgrade it.
+func (c *Constraint) Accepts(v string) (bool, error) {
+	r, err := c.compile()
+	if err != nil {
+		return false, err
+	}
+	return r.accepts(v), nil
+}
+
+// Select returns the version Gradle would resolve c to from candidates, or ""
+// if none satisfies c. A non-dynamic strict or required version selects that
+// version. Otherwise the preferred version is selected if it is acceptable,
+// and the newest acceptable candidate if not.
+func (c *Constraint) Select(candidates []string) (string, error) {
+	r, err := c.compile()
+	if err != nil {
+		return "", err
+	}
+	var want *Version
+	switch {
+	case r.primary != nil && !r.primary.Dynamic():
+		want = New(r.primary.String())
+	case r.primary == nil:
+		want = r.prefer
+	}
+
+	var best *Version
+	for _, s := range candidates {
+		if !r.accepts(s) {
+			continue
+		}
+		v := New(s)
+		if want != nil {
+			if v.Equal(want) {
+				return s, nil
+			}
+			continue
+		}
+		if r.prefer != nil && v.Equal(r.prefer) {
+			return s, nil
+		}
+		if best == nil || v.Compare(best) > 0 {
+			best = v
+		}
+	}
+	if best == nil {
+		return "", nil
+	}
+	return best.unparsed, nil
+}
+
+func (c *Constraint) String() string {
+	var parts []string
+	add := func(name, value string) {
+		if value != "" {
+			parts = append(parts, name+" "+value)
+		}
+	}
+	add("strictly", c.Strictly)
+	add("require", c.Require)
+	add("prefer", c.Prefer)
+	for _, reject := range c.Reject {
+		add("reject", reject)
+	}
+	return "{" + strings.Join(parts, "; ") + "}"
+}
@@ -0,0 +1,120 @@
+package gradle
+
+import "testing"
+
+var candidates = []string{"1.0", "1.4", "1.5", "1.6", "1.7-SNAPSHOT", "2.0", "2.1"}
+
+func TestConstraintSelect(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Constraint
+		want string
+	}{
+		{"empty", Constraint{}, "2.1"},
+		{"require", Constraint{Require: "1.5"}, "1.5"},
+		{"require missing", Constraint{Require: "1.3"}, ""},
+		{"require dynamic", Constraint{Require: "1.+"}, "1.7-SNAPSHOT"},
+		{"require range", Constraint{Require: "[1.0,2.0)"}, "1.7-SNAPSHOT"},
+		{"require range prefer", Constraint{Require: "[1.0,2.0)", Prefer: "1.5"}, "1.5"},
+		{"prefer rejected", Constraint{Require: "[1.0,2.0)", Prefer: "1.5", Reject: []string{"1.5"}}, "1.7-SNAPSHOT"},
+		{"prefer outside range", Constraint{Require: "[1.0,2.0)", Prefer: "2.1"}, "1.7-SNAPSHOT"},
+		{"prefer only", Constraint{Prefer: "1.4"}, "1.4"},
+		{"strictly", Constraint{Strictly: "1.6"}, "1.6"},
+		{"strictly range", Constraint{Strictly: "[1.0,1.6]"}, "1.6"},
+		{"strictly over require", Constraint{Strictly: "[1.0,1.6)", Require: "2.0"}, "1.5"},
+		{"reject range", Constraint{Require: "latest.integration", Reject: []string{"[2.0,)"}}, "1.7-SNAPSHOT"},
+		{"latest release", Constraint{Require: "latest.release", Reject: []string{"2.+"}}, "1.6"},
+		{"rejected require", Constraint{Require: "1.5", Reject: []string{"1.5"}}, ""},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.Select(candidates)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraintAccepts(t *testing.T) {
+	tests := []struct {
+		c       Constraint
+		v       string
+		accepts bool
+	}{
+		{Constraint{Require: "1.5"}, "1.5", true},
+		{Constraint{Require: "1.5"}, "2.0", true},
+		{Constraint{Require: "1.5"}, "1.4", false},
+		{Constraint{Strictly: "1.5"}, "2.0", false},
+		{Constraint{Strictly: "[1.0,2.0)"}, "1.9", true},
+		{Constraint{Require: "1.5", Reject: []string{"1.6"}}, "1.6", false},
+		{Constraint{Prefer: "1.5"}, "0.1", true},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.c.String()+" "+tt.v, func(t *testing.T) {
+			got, err := tt.c.Accepts(tt.v)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got != tt.accepts {
+				t.Errorf("got %v, want %v", got, tt.accepts)
+			}
+		})
+	}
+}
+
+func TestParseConstraint(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Constraint
+	}{
+		{"1.5", Constraint{Require: "1.5"}},
+		{"[1.0,2.0)", Constraint{Require: "[1.0,2.0)"}},
+		{"1.5!!", Constraint{Strictly: "1.5"}},
+		{"[1.0,2.0[!!1.5", Constraint{Strictly: "[1.0,2.0[", Prefer: "1.5"}},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseConstraint(tt.in)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got.String() != tt.want.String() {
+				t.Errorf("got %s, want %s", got, &tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraintErrors(t *testing.T) {
+	for _, s := range []string{"", "!!1.5", "[1.0", "latest.nightly!!1.0"} {
+		if _, err := ParseConstraint(s); err == nil {
+			t.Errorf("ParseConstraint(%q): got nil, want error", s)
+		}
+	}
+
+	c := Constraint{Require: "1.0", Reject: []string{"[1.0"}}
+	if _, err := c.Accepts("1.0"); err == nil {
+		t.Error("Accepts: got nil, want error")
+	}
+	if _, err := c.Select(candidates); err == nil {
+		t.Error("Select: got nil, want error")
+	}
+}
+
+func TestConstraintString(t *testing.T) {
+	c := Constraint{Strictly: "[1.0,2.0)", Prefer: "1.5", Reject: []string{"1.6", "1.7"}}
+	want := "{strictly [1.0,2.0); prefer 1.5; reject 1.6; reject 1.7}"
+	if got := c.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
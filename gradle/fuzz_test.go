@@ -0,0 +1,48 @@
+package gradle
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wfscheper/vercmp/internal/ordertest"
+)
+
+func FuzzNew(f *testing.F) {
+	for _, v := range versionOrder {
+		f.Add(v)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		v := New(s)
+		if v.String() != s {
+			t.Errorf("String: got %q, want %q", v, s)
+		}
+		// The parts hold every byte of the trimmed version except the
+		// separators, and no part mixes digits with other bytes.
+		var joined strings.Builder
+		for i, p := range v.parts {
+			if p.s == "" || strings.ContainsAny(p.s, ".-_+") {
+				t.Fatalf("New(%q): part %d is %q", s, i, p.s)
+			}
+			for j := 1; j < len(p.s); j++ {
+				if isDigit(p.s[j]) != isDigit(p.s[0]) {
+					t.Errorf("New(%q): part %d is %q", s, i, p.s)
+					break
+				}
+			}
+			joined.WriteString(p.s)
+		}
+		want := strings.NewReplacer(".", "", "-", "", "_", "", "+", "").Replace(strings.TrimSpace(s))
+		if joined.String() != want {
+			t.Errorf("New(%q): parts join to %q, want %q", s, joined.String(), want)
+		}
+		// Compare only short-circuits on equal strings, so padding s makes it
+		// compare the parts.
+		if r := v.Compare(New(" " + s + " ")); r != 0 {
+			t.Errorf("Compare(%q, padded): got %d, want 0", s, r)
+		}
+	})
+}
+
+func FuzzVercmp(f *testing.F) {
+	ordertest.Fuzz(f, versionOrder, func(a, b string) int { return Vercmp(a, b) })
+}
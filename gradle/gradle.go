@@ -0,0 +1,191 @@
+// Package gradle compares versions the way Gradle's dependency resolution
+// does.
+//
+// Gradle orders versions much like Maven but by different rules. A version is
+// split into parts at '.', '-', '_' and '+', and wherever digits and letters
+// meet. Numeric parts compare numerically and are newer than any word. Words
+// compare case-sensitively, except for the special words dev, rc, snapshot,
+// final, ga, release and sp, which rank in that order; dev is older and the
+// others newer than any other word. When one version has more parts, an extra
+// numeric part makes it newer and an extra word makes it older, so 1.0 <
+// 1.0.1 but 1.0-beta < 1.0.
+package gradle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wfscheper/vercmp/cache"
+)
+
+// specials are the ranks of the words with special meaning. Other words rank
+// 0.
+var specials = map[string]int{
+	"dev":      -1,
+	"rc":       1,
+	"snapshot": 2,
+	"final":    3,
+	"ga":       4,
+	"release":  5,
+	"sp":       6,
+}
+
+// Version represents a parsed Gradle version string.
+type Version struct {
+	unparsed string
+	parts    []part
+}
+
+type part struct {
+	s       string
+	n       int64
+	numeric bool
+}
+
+// New returns a new Version parsed from the version string v.
+func New(v string) *Version {
+	s := strings.TrimSpace(v)
+	var parts []part
+	start := 0
+	flush := func(end int) {
+		if end > start {
+			parts = append(parts, newPart(s[start:end]))
+		}
+		start = end
+	}
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch == '.' || ch == '-' || ch == '_' || ch == '+':
+			flush(i)
+			start = i + 1
+		case i > start && isDigit(ch) != isDigit(s[i-1]):
+			flush(i)
+		}
+	}
+	flush(len(s))
+	return &Version{v, parts}
+}
+
+func newPart(s string) part {
+	if isDigit(s[0]) {
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return part{s, n, true}
+		}
+	}
+	return part{s: s}
+}
+
+func isDigit(ch byte) bool {
+	return '0' <= ch && ch <= '9'
+}
+
+// String returns the original Gradle version.
+func (v *Version) String() string {
+	return v.unparsed
+}
+
+// Compare compares v with other, and returns a negative integer if v is older
+// than other, 0 if they are equal, or a positive integer if v is newer than
+// other.
+func (v *Version) Compare(other *Version) int {
+	if v.unparsed == other.unparsed {
+		return 0
+	}
+	n := len(v.parts)
+	if len(other.parts) < n {
+		n = len(other.parts)
+	}
+	for i := 0; i < n; i++ {
+		if c := comparePart(v.parts[i], other.parts[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.parts) > n:
+		if v.parts[n].numeric {
+			return 1
+		}
+		return -1
+	case len(other.parts) > n:
+		if other.parts[n].numeric {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+func comparePart(a, b part) int {
+	switch {
+	case a.s == b.s:
+		return 0
+	case a.numeric && b.numeric:
+		return compareInt64(a.n, b.n)
+	case a.numeric:
+		return 1
+	case b.numeric:
+		return -1
+	}
+	ra, aSpecial := specials[strings.ToLower(a.s)]
+	rb, bSpecial := specials[strings.ToLower(b.s)]
+	if aSpecial || bSpecial {
+		return ra - rb
+	}
+	return strings.Compare(a.s, b.s)
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Equal reports whether v and other are the same version.
+func (v *Version) Equal(other *Version) bool {
+	return v.Compare(other) == 0
+}
+
+// LessThan reports whether v is older than other.
+func (v *Version) LessThan(other *Version) bool {
+	return v.Compare(other) < 0
+}
+
+// Vercmp compares two Gradle versions, a and b, and returns a negative
+// integer if a is older than b, 0 if a and b are equal, or a positive integer
+// if a is newer than b. a and b can be either a string or a Version. Vercmp
+// panics if a or b is of any other type.
+func Vercmp(a, b interface{}) int {
+	return toVersion(a).Compare(toVersion(b))
+}
+
+var parseCache = cache.New(0)
+
+// ParseCache returns the cache of parsed versions that Vercmp uses for strings.
+// It starts disabled; Resize it to enable it.
+func ParseCache() *cache.Cache {
+	return parseCache
+}
+
+// toVersion returns v as a *Version, parsing it if it is a string.
+func toVersion(v interface{}) *Version {
+	switch v := v.(type) {
+	case string:
+		if cached, ok := parseCache.Get(v); ok {
+			return cached.(*Version)
+		}
+		parsed := New(v)
+		parseCache.Add(v, parsed)
+		return parsed
+	case *Version:
+		return v
+	case Version:
+		return &v
+	default:
+		panic(fmt.Sprintf("Unparsable type %T", v))
+	}
+}
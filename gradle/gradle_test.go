@@ -0,0 +1,170 @@
+package gradle
+
+import (
+	"testing"
+
+	"github.com/wfscheper/vercmp/maven"
+)
+
+// versionOrder is ordered from oldest to newest.
+var versionOrder = []string{
+	"0.9",
+	"1.0-dev",
+	"1.0-Alpha",
+	"1.0-alpha",
+	"1.0-alpha-2",
+	"1.0-beta",
+	"1.0-rc",
+	"1.0-rc-1",
+	"1.0-rc-2",
+	"1.0-snapshot",
+	"1.0-final",
+	"1.0-ga",
+	"1.0-release",
+	"1.0-sp",
+	"1.0",
+	"1.0.0",
+	"1.0.1",
+	"1.2",
+	"1.10",
+	"1.10.0-rc1",
+	"1.10.0",
+	"2",
+}
+
+func sign(i int) int {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	}
+	return 0
+}
+
+func TestVercmp(t *testing.T) {
+	for i, a := range versionOrder {
+		for j, b := range versionOrder {
+			if got, want := sign(Vercmp(a, b)), sign(i-j); got != want {
+				t.Errorf("Vercmp(%s, %s): got %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestVercmpEqual(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"1.0", "1.0"},
+		{"1.0", "1-0"},
+		{"1.0a", "1.0.a"},
+		{"1_0+a", "1.0-a"},
+		{"1.01", "1.1"},
+		{"1.0-RC1", "1.0-rc1"},
+		{"1.0-SNAPSHOT", "1.0-snapshot"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			if got := Vercmp(tt.a, tt.b); got != 0 {
+				t.Errorf("got %d, want 0", got)
+			}
+			if got := Vercmp(tt.b, tt.a); got != 0 {
+				t.Errorf("reversed: got %d, want 0", got)
+			}
+		})
+	}
+}
+
+// TestMavenDisagreements documents pairs that Gradle orders differently from
+// Maven.
+func TestMavenDisagreements(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		// Gradle ranks dev below every qualifier, Maven above.
+		{"1.0-dev", "1.0-alpha"},
+		// Gradle treats a trailing zero as newer, Maven ignores it.
+		{"1.0", "1.0.0"},
+		// Gradle ranks sp below the release, Maven above.
+		{"1.0-sp", "1.0"},
+		// Gradle compares unknown words case-sensitively.
+		{"1.0-Foo", "1.0-bar"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			if got := sign(Vercmp(tt.a, tt.b)); got != -1 {
+				t.Errorf("gradle: got %d, want -1", got)
+			}
+			if got := sign(maven.Vercmp(tt.a, tt.b)); got == -1 {
+				t.Errorf("maven: got %d, want a different order", got)
+			}
+		})
+	}
+}
+
+func TestVersionMethods(t *testing.T) {
+	a, b := New("1.0"), New("1.1")
+	if a.Compare(b) >= 0 || b.Compare(a) <= 0 || a.Compare(a) != 0 {
+		t.Error("Compare: got wrong order")
+	}
+	if !a.LessThan(b) || b.LessThan(a) || a.LessThan(a) {
+		t.Error("LessThan: got wrong order")
+	}
+	if !a.Equal(New("1-0")) || a.Equal(b) {
+		t.Error("Equal: got wrong result")
+	}
+	if got := New(" 1.0 ").String(); got != " 1.0 " {
+		t.Errorf("String: got %q, want %q", got, " 1.0 ")
+	}
+}
+
+func TestVercmpTypes(t *testing.T) {
+	for _, a := range []interface{}{"1.0", New("1.0"), *New("1.0")} {
+		for _, b := range []interface{}{"1.1", New("1.1"), *New("1.1")} {
+			if got := sign(Vercmp(a, b)); got != -1 {
+				t.Errorf("Vercmp(%#v, %#v): got %d, want -1", a, b, got)
+			}
+		}
+	}
+}
+
+func TestVercmpUnsupportedType(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("got no panic, want panic")
+		}
+	}()
+	Vercmp(1, "1.0")
+}
+
+func TestLongNumbers(t *testing.T) {
+	// Numbers too long for an int64 are compared as words.
+	if got := sign(Vercmp("1.99999999999999999999", "1.2")); got != -1 {
+		t.Errorf("got %d, want -1", got)
+	}
+	if got := sign(Vercmp("1.9223372036854775807", "1.9223372036854775806")); got != 1 {
+		t.Errorf("got %d, want 1", got)
+	}
+}
+
+func TestParseCache(t *testing.T) {
+	c := ParseCache()
+	if got := c.Stats().Capacity; got != 0 {
+		t.Errorf("default capacity: got %d, want a disabled cache", got)
+	}
+	c.Resize(100)
+	defer c.Resize(0)
+	c.Purge()
+	defer c.Purge()
+
+	Vercmp("1.0-rc-1", "1.0")
+	Vercmp("1.0-rc-1", "1.0")
+	if got := c.Stats(); got.Hits != 2 || got.Misses != 2 || got.Len != 2 {
+		t.Errorf("after comparing the same strings twice: got %+v, want 2 hits, 2 misses and 2 versions", got)
+	}
+}
@@ -0,0 +1,184 @@
+package gradle
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Selector selects the versions that a Gradle version notation accepts.
+type Selector interface {
+	// Accepts reports whether the selector accepts version v.
+	Accepts(v string) bool
+	// Dynamic reports whether the selector can accept more than one version.
+	Dynamic() bool
+	String() string
+}
+
+// Statuses lists the Gradle module statuses from least to most mature.
+// Versions ending in SNAPSHOT have status integration and all others
+// release.
+var Statuses = []string{"integration", "milestone", "release"}
+
+// ParseSelector parses a Gradle version notation:
+//
+//	1.2.3          a single version
+//	1.2.+, 1.2+    any version that starts with the text before '+'
+//	+              any version
+//	latest.release any version whose status is at least the named status
+//	[1.0,2.0)      a range; ']' and '[' may replace '(' and ')'
+func ParseSelector(s string) (Selector, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+		return nil, fmt.Errorf("invalid version selector %q", s)
+	case strings.HasSuffix(s, "+"):
+		return prefixSelector(s[:len(s)-1]), nil
+	case strings.HasPrefix(s, "latest."):
+		status := s[len("latest."):]
+		for i, known := range Statuses {
+			if status == known {
+				return latestSelector(i), nil
+			}
+		}
+		return nil, fmt.Errorf("invalid version selector %q: unknown status %q", s, status)
+	case strings.ContainsAny(s[:1], "[](") || strings.ContainsAny(s[len(s)-1:], "[])"):
+		return parseRange(s)
+	}
+	return exactSelector{New(s)}, nil
+}
+
+// exactSelector accepts a single version.
+type exactSelector struct {
+	v *Version
+}
+
+func (s exactSelector) Accepts(v string) bool {
+	return s.v.Compare(New(v)) == 0
+}
+
+func (s exactSelector) Dynamic() bool {
+	return false
+}
+
+func (s exactSelector) String() string {
+	return s.v.String()
+}
+
+// prefixSelector accepts the versions that start with its text.
+type prefixSelector string
+
+func (s prefixSelector) Accepts(v string) bool {
+	return strings.HasPrefix(v, string(s))
+}
+
+func (s prefixSelector) Dynamic() bool {
+	return true
+}
+
+func (s prefixSelector) String() string {
+	return string(s) + "+"
+}
+
+// latestSelector accepts the versions whose status is at least Statuses[s].
+type latestSelector int
+
+func (s latestSelector) Accepts(v string) bool {
+	status := len(Statuses) - 1
+	if strings.HasSuffix(strings.ToUpper(v), "SNAPSHOT") {
+		status = 0
+	}
+	return status >= int(s)
+}
+
+func (s latestSelector) Dynamic() bool {
+	return true
+}
+
+func (s latestSelector) String() string {
+	return "latest." + Statuses[s]
+}
+
+// rangeSelector accepts the versions within its bounds. A nil bound leaves
+// that side of the range open.
+type rangeSelector struct {
+	lower, upper                   *Version
+	lowerInclusive, upperInclusive bool
+}
+
+func parseRange(s string) (Selector, error) {
+	if len(s) < 3 {
+		return nil, fmt.Errorf("invalid version range %q", s)
+	}
+	r := rangeSelector{
+		lowerInclusive: s[0] == '[',
+		upperInclusive: s[len(s)-1] == ']',
+	}
+	if !strings.ContainsAny(s[:1], "[](") || !strings.ContainsAny(s[len(s)-1:], "[])") {
+		return nil, fmt.Errorf("invalid version range %q: unbalanced brackets", s)
+	}
+	inner := s[1 : len(s)-1]
+	comma := strings.IndexByte(inner, ',')
+	if comma < 0 || strings.ContainsAny(inner, "[]()") || strings.Count(inner, ",") > 1 {
+		return nil, fmt.Errorf("invalid version range %q", s)
+	}
+	if lower := strings.TrimSpace(inner[:comma]); lower != "" {
+		r.lower = New(lower)
+	} else if r.lowerInclusive {
+		return nil, fmt.Errorf("invalid version range %q: unbounded range must be exclusive", s)
+	}
+	if upper := strings.TrimSpace(inner[comma+1:]); upper != "" {
+		r.upper = New(upper)
+	} else if r.upperInclusive {
+		return nil, fmt.Errorf("invalid version range %q: unbounded range must be exclusive", s)
+	}
+	if r.lower == nil && r.upper == nil {
+		return nil, fmt.Errorf("invalid version range %q: no bounds", s)
+	}
+	if r.lower != nil && r.upper != nil && r.upper.Compare(r.lower) < 0 {
+		return nil, fmt.Errorf("invalid version range %q: defies version ordering", s)
+	}
+	return r, nil
+}
+
+func (r rangeSelector) Accepts(s string) bool {
+	v := New(s)
+	if r.lower != nil {
+		c := v.Compare(r.lower)
+		if c < 0 || c == 0 && !r.lowerInclusive {
+			return false
+		}
+	}
+	if r.upper != nil {
+		c := v.Compare(r.upper)
+		if c > 0 || c == 0 && !r.upperInclusive {
+			return false
+		}
+	}
+	return true
+}
+
+func (r rangeSelector) Dynamic() bool {
+	return true
+}
+
+func (r rangeSelector) String() string {
+	var b strings.Builder
+	if r.lowerInclusive {
+		b.WriteByte('[')
+	} else {
+		b.WriteByte('(')
+	}
+	if r.lower != nil {
+		b.WriteString(r.lower.String())
+	}
+	b.WriteByte(',')
+	if r.upper != nil {
+		b.WriteString(r.upper.String())
+	}
+	if r.upperInclusive {
+		b.WriteByte(']')
+	} else {
+		b.WriteByte(')')
+	}
+	return b.String()
+}
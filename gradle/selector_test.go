@@ -0,0 +1,99 @@
+package gradle
+
+import "testing"
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		in      string
+		dynamic bool
+		accepts []string
+		rejects []string
+	}{
+		{"1.2.3", false, []string{"1.2.3", "1-2-3"}, []string{"1.2.3.0", "1.2.4"}},
+		{"1.2.+", true, []string{"1.2.0", "1.2.10", "1.2.3-rc1"}, []string{"1.2", "1.3.0", "1.20"}},
+		{"1.2+", true, []string{"1.2", "1.2.0", "1.20"}, []string{"1.3"}},
+		{"+", true, []string{"0.1", "1.0-SNAPSHOT"}, nil},
+		{"latest.release", true, []string{"1.0", "2.0-rc1"}, []string{"2.0-SNAPSHOT"}},
+		{"latest.milestone", true, []string{"1.0"}, []string{"2.0-SNAPSHOT"}},
+		{"latest.integration", true, []string{"1.0", "2.0-SNAPSHOT"}, nil},
+		{"[1.0,2.0)", true, []string{"1.0", "1.5", "2.0-rc1"}, []string{"0.9", "2.0"}},
+		{"]1.0,2.0]", true, []string{"1.0.1", "2.0"}, []string{"1.0", "2.0.1"}},
+		{"[1.0,2.0[", true, []string{"1.0", "1.9"}, []string{"2.0"}},
+		{"[1.0,)", true, []string{"1.0", "99"}, []string{"1.0-rc1"}},
+		{"(,2.0]", true, []string{"0.1", "2.0"}, []string{"2.0.1"}},
+		{"],2.0[", true, []string{"1.9"}, []string{"2.0"}},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			s, err := ParseSelector(tt.in)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got := s.Dynamic(); got != tt.dynamic {
+				t.Errorf("Dynamic: got %v, want %v", got, tt.dynamic)
+			}
+			for _, v := range tt.accepts {
+				if !s.Accepts(v) {
+					t.Errorf("Accepts(%s): got false, want true", v)
+				}
+			}
+			for _, v := range tt.rejects {
+				if s.Accepts(v) {
+					t.Errorf("Accepts(%s): got true, want false", v)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectorString(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{" 1.2.3 ", "1.2.3"},
+		{"1.2.+", "1.2.+"},
+		{"latest.release", "latest.release"},
+		{"[1.0,2.0)", "[1.0,2.0)"},
+		{"]1.0, 2.0[", "(1.0,2.0)"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			s, err := ParseSelector(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := s.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSelectorErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"latest.nightly",
+		"[1.0]",
+		"[1.0,2.0",
+		"1.0,2.0)",
+		"[,2.0)",
+		"(1.0,]",
+		"(,)",
+		"[1.0,2.0,3.0]",
+		"[2.0,1.0]",
+		"[1.0,[2.0]",
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			if _, err := ParseSelector(tt); err == nil {
+				t.Error("got nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Package benchtest holds the helpers that the benchmarks of each version
+// scheme share.
+//
+// The allocation budgets that Allocs checks depend on the Go toolchain, so
+// they only run in builds with the allocs tag:
+//
+//	go test -tags allocs -run Allocs ./...
+package benchtest
+
+import (
+	"testing"
+
+	"github.com/wfscheper/vercmp/cache"
+	"github.com/wfscheper/vercmp/internal/race"
+)
+
+// EnableCache resizes c to hold up to capacity values, and returns a
+// function that disables it again.
+func EnableCache(c *cache.Cache, capacity int) (restore func()) {
+	c.Resize(capacity)
+	return func() { c.Resize(0) }
+}
+
+// Allocs reports an error if f makes more allocations than budget. The
+// budgets are the counts of the current toolchain, so lower one when a change
+// saves allocations, and never raise one without a reason.
+func Allocs(t *testing.T, name string, budget float64, f func()) {
+	t.Helper()
+	if !enabled {
+		t.Skip("allocation budgets need the allocs build tag")
+	}
+	if race.Enabled {
+		t.Skip("the race detector changes allocations")
+	}
+	if got := testing.AllocsPerRun(100, f); got > budget {
+		t.Errorf("%s: got %v allocations, want at most %v", name, got, budget)
+	}
+}
@@ -0,0 +1,5 @@
+//go:build !allocs
+
+package benchtest
+
+const enabled = false
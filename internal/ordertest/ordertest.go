@@ -0,0 +1,87 @@
+// Package ordertest checks that a version comparison obeys the laws of an
+// ordering. It is meant for fuzz tests, which feed it arbitrary versions.
+package ordertest
+
+import "testing"
+
+// Compare compares two versions, and returns a negative integer if a is older
+// than b, 0 if they are equal, or a positive integer if a is newer than b.
+type Compare func(a, b string) int
+
+// Reflexive reports an error if a does not compare equal to itself.
+func Reflexive(t testing.TB, cmp Compare, a string) {
+	t.Helper()
+	if r := cmp(a, a); r != 0 {
+		t.Errorf("reflexivity: %q vs itself is %d", a, r)
+	}
+}
+
+// Antisymmetric reports an error if comparing a with b does not give the
+// opposite of comparing b with a.
+func Antisymmetric(t testing.TB, cmp Compare, a, b string) {
+	t.Helper()
+	if ab, ba := sign(cmp(a, b)), sign(cmp(b, a)); ab != -ba {
+		t.Errorf("antisymmetry: %q vs %q is %d, but %q vs %q is %d", a, b, ab, b, a, ba)
+	}
+}
+
+// Transitive reports an error if a, b and c, taken in any order x, y, z, have
+// x <= y and y <= z but not x <= z, or x < z if either of the first is strict.
+func Transitive(t testing.TB, cmp Compare, a, b, c string) {
+	t.Helper()
+	vs := [3]string{a, b, c}
+	var r [3][3]int
+	for i := range vs {
+		for j := range vs {
+			r[i][j] = sign(cmp(vs[i], vs[j]))
+		}
+	}
+	for _, p := range [6][3]int{{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0}} {
+		x, y, z := p[0], p[1], p[2]
+		if r[x][y] > 0 || r[y][z] > 0 {
+			continue
+		}
+		want := 0
+		if r[x][y] < 0 || r[y][z] < 0 {
+			want = -1
+		}
+		if r[x][z] != want {
+			t.Errorf("transitivity: %q vs %q is %d and %q vs %q is %d, but %q vs %q is %d",
+				vs[x], vs[y], r[x][y], vs[y], vs[z], r[y][z], vs[x], vs[z], r[x][z])
+		}
+	}
+}
+
+// Laws checks that cmp is reflexive, antisymmetric and transitive for a, b and
+// c.
+func Laws(t testing.TB, cmp Compare, a, b, c string) {
+	t.Helper()
+	for _, v := range []string{a, b, c} {
+		Reflexive(t, cmp, v)
+	}
+	Antisymmetric(t, cmp, a, b)
+	Antisymmetric(t, cmp, b, c)
+	Antisymmetric(t, cmp, a, c)
+	Transitive(t, cmp, a, b, c)
+}
+
+// Fuzz fuzzes cmp with Laws, seeded with each run of three consecutive
+// versions in order.
+func Fuzz(f *testing.F, order []string, cmp Compare) {
+	for i := 0; i+2 < len(order); i++ {
+		f.Add(order[i], order[i+1], order[i+2])
+	}
+	f.Fuzz(func(t *testing.T, a, b, c string) {
+		Laws(t, cmp, a, b, c)
+	})
+}
+
+func sign(i int) int {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,8 @@
+//go:build !race
+
+// Package race reports whether the race detector is enabled, which changes
+// how much some operations allocate.
+package race
+
+// Enabled reports whether the race detector is enabled.
+const Enabled = false
@@ -0,0 +1,340 @@
+// Package sqltest provides a minimal in-memory database/sql driver that
+// stands in for SQLite in tests.
+//
+// The driver understands just enough SQL to store and query versions:
+//
+//	CREATE TABLE name (col, ...)
+//	INSERT INTO name VALUES (?, ...)
+//	SELECT col, ... FROM name [WHERE col op ? [AND col op ?]...] [ORDER BY col]
+//
+// where op is one of =, <, <=, > or >=. Text and blob values are compared
+// bytewise, as SQLite does for BLOB columns.
+package sqltest
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DriverName is the name the driver is registered under.
+const DriverName = "sqltest"
+
+func init() {
+	sql.Register(DriverName, &sqlDriver{dbs: make(map[string]*database)})
+}
+
+type sqlDriver struct {
+	mu  sync.Mutex
+	dbs map[string]*database
+}
+
+// Open returns a connection to the database named by dsn, creating it if it
+// does not exist. Connections with the same dsn share their tables.
+func (d *sqlDriver) Open(dsn string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	db, ok := d.dbs[dsn]
+	if !ok {
+		db = &database{tables: make(map[string]*table)}
+		d.dbs[dsn] = db
+	}
+	return &conn{db}, nil
+}
+
+type database struct {
+	mu     sync.Mutex
+	tables map[string]*table
+}
+
+type table struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (t *table) column(name string) (int, error) {
+	for i, c := range t.columns {
+		if c == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("sqltest: no such column: %s", name)
+}
+
+type conn struct {
+	db *database
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{c.db, strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ", ",", " , ").Replace(query))}, nil
+}
+
+func (c *conn) Close() error { return nil }
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return nil, errors.New("sqltest: transactions are not supported")
+}
+
+type stmt struct {
+	db     *database
+	tokens []string
+}
+
+func (s *stmt) Close() error { return nil }
+
+func (s *stmt) NumInput() int {
+	n := 0
+	for _, t := range s.tokens {
+		if t == "?" {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	switch keyword(s.tokens, 0) {
+	case "CREATE":
+		if keyword(s.tokens, 1) != "TABLE" || len(s.tokens) < 3 {
+			return nil, s.syntaxError()
+		}
+		s.db.tables[s.tokens[2]] = &table{columns: names(s.tokens[3:])}
+		return driver.RowsAffected(0), nil
+	case "INSERT":
+		if keyword(s.tokens, 1) != "INTO" || len(s.tokens) < 3 {
+			return nil, s.syntaxError()
+		}
+		t, err := s.table(s.tokens[2])
+		if err != nil {
+			return nil, err
+		}
+		if len(args) != len(t.columns) {
+			return nil, fmt.Errorf("sqltest: %d values for %d columns", len(args), len(t.columns))
+		}
+		row := make([]driver.Value, len(args))
+		for i, a := range args {
+			if b, ok := a.([]byte); ok {
+				a = append([]byte(nil), b...)
+			}
+			row[i] = a
+		}
+		t.rows = append(t.rows, row)
+		return driver.RowsAffected(1), nil
+	}
+	return nil, s.syntaxError()
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if keyword(s.tokens, 0) != "SELECT" {
+		return nil, s.syntaxError()
+	}
+	from := index(s.tokens, "FROM")
+	if from < 0 || from+1 >= len(s.tokens) {
+		return nil, s.syntaxError()
+	}
+	t, err := s.table(s.tokens[from+1])
+	if err != nil {
+		return nil, err
+	}
+	var cols []int
+	selected := names(s.tokens[1:from])
+	for _, name := range selected {
+		i, err := t.column(name)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, i)
+	}
+
+	rest := s.tokens[from+2:]
+	order := -1
+	if i := index(rest, "ORDER"); i >= 0 {
+		if keyword(rest, i+1) != "BY" || i+2 >= len(rest) {
+			return nil, s.syntaxError()
+		}
+		if order, err = t.column(rest[i+2]); err != nil {
+			return nil, err
+		}
+		rest = rest[:i]
+	}
+
+	var rows [][]driver.Value
+	for _, row := range t.rows {
+		ok, err := s.match(t, row, rest, args)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rows = append(rows, row)
+		}
+	}
+	if order >= 0 {
+		sort.SliceStable(rows, func(i, j int) bool {
+			return compare(rows[i][order], rows[j][order]) < 0
+		})
+	}
+
+	r := &resultRows{columns: selected}
+	for _, row := range rows {
+		out := make([]driver.Value, len(cols))
+		for i, c := range cols {
+			out[i] = row[c]
+		}
+		r.rows = append(r.rows, out)
+	}
+	return r, nil
+}
+
+// match reports whether row satisfies the WHERE clause in tokens.
+func (s *stmt) match(t *table, row []driver.Value, tokens []string, args []driver.Value) (bool, error) {
+	if len(tokens) == 0 {
+		return true, nil
+	}
+	if keyword(tokens, 0) != "WHERE" {
+		return false, s.syntaxError()
+	}
+	tokens = tokens[1:]
+	for len(tokens) > 0 {
+		if len(tokens) < 3 || tokens[2] != "?" || len(args) == 0 {
+			return false, s.syntaxError()
+		}
+		col, err := t.column(tokens[0])
+		if err != nil {
+			return false, err
+		}
+		c := compare(row[col], args[0])
+		var ok bool
+		switch tokens[1] {
+		case "=":
+			ok = c == 0
+		case "<":
+			ok = c < 0
+		case "<=":
+			ok = c <= 0
+		case ">":
+			ok = c > 0
+		case ">=":
+			ok = c >= 0
+		default:
+			return false, s.syntaxError()
+		}
+		if !ok {
+			return false, nil
+		}
+		tokens, args = tokens[3:], args[1:]
+		if len(tokens) > 0 {
+			if keyword(tokens, 0) != "AND" {
+				return false, s.syntaxError()
+			}
+			tokens = tokens[1:]
+		}
+	}
+	return true, nil
+}
+
+func (s *stmt) table(name string) (*table, error) {
+	t, ok := s.db.tables[name]
+	if !ok {
+		return nil, fmt.Errorf("sqltest: no such table: %s", name)
+	}
+	return t, nil
+}
+
+func (s *stmt) syntaxError() error {
+	return fmt.Errorf("sqltest: unsupported statement: %s", strings.Join(s.tokens, " "))
+}
+
+type resultRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *resultRows) Columns() []string { return r.columns }
+
+func (r *resultRows) Close() error { return nil }
+
+func (r *resultRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+// compare orders two driver values. Strings and byte slices are compared
+// bytewise, integers numerically, and NULL sorts first.
+func compare(a, b driver.Value) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		default:
+			return 1
+		}
+	}
+	if ai, ok := a.(int64); ok {
+		if bi, ok := b.(int64); ok {
+			switch {
+			case ai < bi:
+				return -1
+			case ai > bi:
+				return 1
+			}
+			return 0
+		}
+	}
+	return bytes.Compare(toBytes(a), toBytes(b))
+}
+
+func toBytes(v driver.Value) []byte {
+	switch v := v.(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	default:
+		return []byte(fmt.Sprint(v))
+	}
+}
+
+// names returns the identifiers in tokens, dropping punctuation.
+func names(tokens []string) []string {
+	var r []string
+	for _, t := range tokens {
+		if t != "(" && t != ")" && t != "," {
+			r = append(r, t)
+		}
+	}
+	return r
+}
+
+func keyword(tokens []string, i int) string {
+	if i >= len(tokens) {
+		return ""
+	}
+	return strings.ToUpper(tokens[i])
+}
+
+func index(tokens []string, kw string) int {
+	for i := range tokens {
+		if keyword(tokens, i) == kw {
+			return i
+		}
+	}
+	return -1
+}
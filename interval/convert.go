@@ -0,0 +1,82 @@
+package interval
+
+import (
+	"fmt"
+
+	"github.com/wfscheper/vercmp"
+	"github.com/wfscheper/vercmp/constraint"
+	"github.com/wfscheper/vercmp/maven"
+)
+
+// FromMavenRange returns the set of maven versions that r allows. A soft
+// requirement, such as "1.0", allows every version.
+func FromMavenRange(r *maven.Range) *Set {
+	intervals := make([]Interval, len(r.Restrictions))
+	for n, restriction := range r.Restrictions {
+		if restriction.Lower != nil {
+			intervals[n].Lower = &Bound{restriction.Lower.String(), restriction.LowerInclusive}
+		}
+		if restriction.Upper != nil {
+			intervals[n].Upper = &Bound{restriction.Upper.String(), restriction.UpperInclusive}
+		}
+	}
+	s, _ := New(vercmp.Maven, intervals...)
+	return s
+}
+
+// FromConstraint returns the set of versions that satisfy c, comparing
+// versions with the scheme registered under name in the vercmp package. It
+// returns an error if the scheme is unknown or a version in c is invalid.
+func FromConstraint(name string, c *constraint.Constraint) (*Set, error) {
+	if _, ok := vercmp.Lookup(name); !ok {
+		return nil, fmt.Errorf("interval: unknown scheme %q", name)
+	}
+	return fromNode(name, c.Root())
+}
+
+func fromNode(name string, n constraint.Node) (*Set, error) {
+	switch n := n.(type) {
+	case *constraint.Comparison:
+		v := n.Version
+		switch n.Op {
+		case constraint.EQ:
+			return New(name, Point(v))
+		case constraint.NE:
+			return New(name, LessThan(v), GreaterThan(v))
+		case constraint.LT:
+			return New(name, LessThan(v))
+		case constraint.LE:
+			return New(name, AtMost(v))
+		case constraint.GT:
+			return New(name, GreaterThan(v))
+		case constraint.GE:
+			return New(name, AtLeast(v))
+		}
+		return nil, fmt.Errorf("interval: unknown operator %v", n.Op)
+	case *constraint.And:
+		x, y, err := fromPair(name, n.X, n.Y)
+		if err != nil {
+			return nil, err
+		}
+		return x.Intersect(y), nil
+	case *constraint.Or:
+		x, y, err := fromPair(name, n.X, n.Y)
+		if err != nil {
+			return nil, err
+		}
+		return x.Union(y), nil
+	}
+	return nil, fmt.Errorf("interval: unknown constraint node %T", n)
+}
+
+func fromPair(name string, x, y constraint.Node) (*Set, *Set, error) {
+	xs, err := fromNode(name, x)
+	if err != nil {
+		return nil, nil, err
+	}
+	ys, err := fromNode(name, y)
+	if err != nil {
+		return nil, nil, err
+	}
+	return xs, ys, nil
+}
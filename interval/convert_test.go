@@ -0,0 +1,79 @@
+package interval
+
+import (
+	"testing"
+
+	"github.com/wfscheper/vercmp"
+	"github.com/wfscheper/vercmp/constraint"
+	"github.com/wfscheper/vercmp/maven"
+)
+
+func TestFromMavenRange(t *testing.T) {
+	tests := []struct {
+		spec, want string
+	}{
+		{"1.0", "(,)"},
+		{"[1.0]", "[1.0]"},
+		{"(,1.0],[1.2,)", "(,1.0],[1.2,)"},
+		{"[1.0,2.0)", "[1.0,2.0)"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			r, err := maven.ParseRange(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := FromMavenRange(r).String(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromConstraint(t *testing.T) {
+	tests := []struct {
+		scheme, expr, want string
+	}{
+		{vercmp.SemVer, ">=1.2.0 && <2.0.0 || ==2.1.0", "[1.2.0,2.0.0),[2.1.0]"},
+		{vercmp.SemVer, "!=1.0.0", "(,1.0.0),(1.0.0,)"},
+		{vercmp.SemVer, "<=1.0.0 || >1.0.0", "(,)"},
+		{vercmp.SemVer, ">2.0.0 && <1.0.0", "{}"},
+		{vercmp.Maven, ">=1.0 && !=1.5 && <2.0", "[1.0,1.5),(1.5,2.0)"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := FromConstraint(tt.scheme, constraint.MustParse(tt.expr))
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got := s.String(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromConstraintErrors(t *testing.T) {
+	if _, err := FromConstraint("unknown", constraint.MustParse("1.0")); err == nil {
+		t.Error("got nil, want error")
+	}
+	if _, err := FromConstraint(vercmp.SemVer, constraint.MustParse(">1.0.0 && <2.0")); err == nil {
+		t.Error("got nil, want error")
+	}
+}
+
+func TestVulnerabilityTriage(t *testing.T) {
+	affected, _ := maven.ParseRange("[2.0,2.14.1],[2.15.0,2.15.3)")
+	fixed, _ := maven.ParseRange("[2.12.2],[2.15.3,)")
+	deployed, _ := maven.ParseRange("[2.10,2.16)")
+
+	exposed := FromMavenRange(affected).Subtract(FromMavenRange(fixed)).Intersect(FromMavenRange(deployed))
+	want := "[2.10,2.12.2),(2.12.2,2.14.1],[2.15.0,2.15.3)"
+	if got := exposed.String(); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,311 @@
+// Package interval implements sets of versions built from intervals, with the
+// set algebra needed to combine version ranges: union, intersection,
+// complement and difference.
+//
+// A Set works with any version scheme registered in the vercmp package, or
+// with any vercmp.CompareFunc. Sets are kept in a normalized form of sorted,
+// disjoint intervals, and print in Maven range notation:
+//
+//	[1.0,2.0),[3.0]
+package interval
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/wfscheper/vercmp"
+)
+
+// Bound is one end of an Interval.
+type Bound struct {
+	Version   string
+	Inclusive bool
+}
+
+// Interval is a contiguous range of versions. A nil bound leaves that side of
+// the interval unbounded.
+type Interval struct {
+	Lower, Upper *Bound
+}
+
+// Closed returns the interval [lower,upper].
+func Closed(lower, upper string) Interval {
+	return Interval{&Bound{lower, true}, &Bound{upper, true}}
+}
+
+// Point returns the interval [v], which holds only v.
+func Point(v string) Interval {
+	return Closed(v, v)
+}
+
+// AtLeast returns the interval [v,).
+func AtLeast(v string) Interval {
+	return Interval{Lower: &Bound{v, true}}
+}
+
+// GreaterThan returns the interval (v,).
+func GreaterThan(v string) Interval {
+	return Interval{Lower: &Bound{v, false}}
+}
+
+// AtMost returns the interval (,v].
+func AtMost(v string) Interval {
+	return Interval{Upper: &Bound{v, true}}
+}
+
+// LessThan returns the interval (,v).
+func LessThan(v string) Interval {
+	return Interval{Upper: &Bound{v, false}}
+}
+
+// String returns i in Maven range notation.
+func (i Interval) String() string {
+	if i.Lower != nil && i.Upper != nil && i.Lower.Inclusive && i.Upper.Inclusive && i.Lower.Version == i.Upper.Version {
+		return "[" + i.Lower.Version + "]"
+	}
+	var b strings.Builder
+	if i.Lower != nil && i.Lower.Inclusive {
+		b.WriteByte('[')
+	} else {
+		b.WriteByte('(')
+	}
+	if i.Lower != nil {
+		b.WriteString(i.Lower.Version)
+	}
+	b.WriteByte(',')
+	if i.Upper != nil {
+		b.WriteString(i.Upper.Version)
+	}
+	if i.Upper != nil && i.Upper.Inclusive {
+		b.WriteByte(']')
+	} else {
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+// Set is a set of versions made of disjoint intervals.
+type Set struct {
+	cmp       vercmp.CompareFunc
+	intervals []Interval
+}
+
+// New returns the union of intervals, comparing versions with the scheme
+// registered under name in the vercmp package. It returns an error if the
+// scheme is unknown or a bound is not a valid version.
+func New(name string, intervals ...Interval) (*Set, error) {
+	cmp, ok := vercmp.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("interval: unknown scheme %q", name)
+	}
+	return NewFunc(cmp, intervals...)
+}
+
+// NewFunc returns the union of intervals, comparing versions with cmp. It
+// returns an error if a bound is not a valid version.
+func NewFunc(cmp vercmp.CompareFunc, intervals ...Interval) (*Set, error) {
+	for _, i := range intervals {
+		for _, b := range []*Bound{i.Lower, i.Upper} {
+			if b == nil {
+				continue
+			}
+			if _, err := cmp(b.Version, b.Version); err != nil {
+				return nil, err
+			}
+		}
+	}
+	s := &Set{cmp: cmp}
+	s.intervals = s.normalize(append([]Interval(nil), intervals...))
+	return s, nil
+}
+
+// Intervals returns the disjoint intervals that make up s, in ascending order.
+func (s *Set) Intervals() []Interval {
+	return append([]Interval(nil), s.intervals...)
+}
+
+// IsEmpty reports whether s holds no versions.
+func (s *Set) IsEmpty() bool {
+	return len(s.intervals) == 0
+}
+
+// Contains reports whether v is in s. It returns an error if v is not a valid
+// version.
+func (s *Set) Contains(v string) (bool, error) {
+	if _, err := s.cmp(v, v); err != nil {
+		return false, err
+	}
+	p := Point(v)
+	for _, i := range s.intervals {
+		if s.lowerCmp(i.Lower, p.Lower) <= 0 && s.upperCmp(p.Upper, i.Upper) <= 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Union returns the versions that are in s or t. Both sets must use the same
+// comparison.
+func (s *Set) Union(t *Set) *Set {
+	intervals := append(append([]Interval(nil), s.intervals...), t.intervals...)
+	return &Set{s.cmp, s.normalize(intervals)}
+}
+
+// Intersect returns the versions that are in both s and t. Both sets must use
+// the same comparison.
+func (s *Set) Intersect(t *Set) *Set {
+	var intervals []Interval
+	for _, a := range s.intervals {
+		for _, b := range t.intervals {
+			i := Interval{a.Lower, a.Upper}
+			if s.lowerCmp(b.Lower, i.Lower) > 0 {
+				i.Lower = b.Lower
+			}
+			if s.upperCmp(b.Upper, i.Upper) < 0 {
+				i.Upper = b.Upper
+			}
+			intervals = append(intervals, i)
+		}
+	}
+	return &Set{s.cmp, s.normalize(intervals)}
+}
+
+// Complement returns the versions that are not in s.
+func (s *Set) Complement() *Set {
+	var intervals []Interval
+	var lower *Bound
+	for n, i := range s.intervals {
+		if n > 0 || i.Lower != nil {
+			intervals = append(intervals, Interval{lower, flip(i.Lower)})
+		}
+		lower = flip(i.Upper)
+	}
+	if len(s.intervals) == 0 || lower != nil {
+		intervals = append(intervals, Interval{Lower: lower})
+	}
+	return &Set{s.cmp, intervals}
+}
+
+// Subtract returns the versions that are in s but not in t. Both sets must use
+// the same comparison.
+func (s *Set) Subtract(t *Set) *Set {
+	return s.Intersect(t.Complement())
+}
+
+// String returns s in Maven range notation. The empty set is "{}".
+func (s *Set) String() string {
+	if len(s.intervals) == 0 {
+		return "{}"
+	}
+	parts := make([]string, len(s.intervals))
+	for n, i := range s.intervals {
+		parts[n] = i.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// normalize sorts intervals, drops the empty ones and merges the ones that
+// overlap or touch.
+func (s *Set) normalize(intervals []Interval) []Interval {
+	nonEmpty := intervals[:0]
+	for _, i := range intervals {
+		if !s.isEmpty(i) {
+			nonEmpty = append(nonEmpty, i)
+		}
+	}
+	sort.SliceStable(nonEmpty, func(a, b int) bool {
+		return s.lowerCmp(nonEmpty[a].Lower, nonEmpty[b].Lower) < 0
+	})
+
+	var merged []Interval
+	for _, i := range nonEmpty {
+		n := len(merged)
+		if n > 0 && s.touches(merged[n-1], i) {
+			if s.upperCmp(i.Upper, merged[n-1].Upper) > 0 {
+				merged[n-1].Upper = i.Upper
+			}
+			continue
+		}
+		merged = append(merged, i)
+	}
+	return merged
+}
+
+// isEmpty reports whether i holds no versions.
+func (s *Set) isEmpty(i Interval) bool {
+	if i.Lower == nil || i.Upper == nil {
+		return false
+	}
+	c := s.compare(i.Lower.Version, i.Upper.Version)
+	return c > 0 || c == 0 && !(i.Lower.Inclusive && i.Upper.Inclusive)
+}
+
+// touches reports whether b, which does not start before a, overlaps a or
+// starts right where a ends.
+func (s *Set) touches(a, b Interval) bool {
+	if a.Upper == nil || b.Lower == nil {
+		return true
+	}
+	c := s.compare(b.Lower.Version, a.Upper.Version)
+	return c < 0 || c == 0 && (a.Upper.Inclusive || b.Lower.Inclusive)
+}
+
+// lowerCmp compares two lower bounds. A nil bound is the lowest, and an
+// inclusive bound is lower than an exclusive one at the same version.
+func (s *Set) lowerCmp(a, b *Bound) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	if c := s.compare(a.Version, b.Version); c != 0 {
+		return c
+	}
+	return boolCmp(b.Inclusive, a.Inclusive)
+}
+
+// upperCmp compares two upper bounds. A nil bound is the highest, and an
+// inclusive bound is higher than an exclusive one at the same version.
+func (s *Set) upperCmp(a, b *Bound) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	if c := s.compare(a.Version, b.Version); c != 0 {
+		return c
+	}
+	return boolCmp(a.Inclusive, b.Inclusive)
+}
+
+// compare compares two versions that are known to be valid.
+func (s *Set) compare(a, b string) int {
+	c, _ := s.cmp(a, b)
+	return c
+}
+
+// flip returns the bound on the other side of b, which holds exactly the
+// versions b leaves out.
+func flip(b *Bound) *Bound {
+	if b == nil {
+		return nil
+	}
+	return &Bound{b.Version, !b.Inclusive}
+}
+
+func boolCmp(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
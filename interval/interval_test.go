@@ -0,0 +1,156 @@
+package interval
+
+import (
+	"testing"
+
+	"github.com/wfscheper/vercmp"
+)
+
+func mustSet(t *testing.T, name string, intervals ...Interval) *Set {
+	t.Helper()
+	s, err := New(name, intervals...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		title     string
+		intervals []Interval
+		want      string
+	}{
+		{"Empty", nil, "{}"},
+		{"All", []Interval{{}}, "(,)"},
+		{"Point", []Interval{Point("1.0")}, "[1.0]"},
+		{"Sorted", []Interval{AtLeast("3.0"), LessThan("1.0")}, "(,1.0),[3.0,)"},
+		{"Overlapping", []Interval{Closed("1.0", "2.0"), Closed("1.5", "3.0")}, "[1.0,3.0]"},
+		{"Contained", []Interval{Closed("1.0", "3.0"), Closed("1.5", "2.0")}, "[1.0,3.0]"},
+		{"Touching", []Interval{LessThan("1.0"), Closed("1.0", "2.0")}, "(,2.0]"},
+		{"Open gap", []Interval{LessThan("1.0"), GreaterThan("1.0")}, "(,1.0),(1.0,)"},
+		{"Equal versions touch", []Interval{AtMost("1.0"), GreaterThan("1")}, "(,)"},
+		{"Empty intervals", []Interval{Closed("2.0", "1.0"), {&Bound{"1.0", false}, &Bound{"1.0", true}}}, "{}"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := mustSet(t, vercmp.Maven, tt.intervals...).String(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if s, err := New("unknown"); err == nil {
+		t.Errorf("got %v, want error", s)
+	}
+	if s, err := New(vercmp.SemVer, Closed("1.0.0", "2.0")); err == nil {
+		t.Errorf("got %v, want error", s)
+	}
+}
+
+func TestContains(t *testing.T) {
+	s := mustSet(t, vercmp.SemVer, Interval{&Bound{"1.0.0", false}, &Bound{"2.0.0", true}}, Point("3.0.0"))
+	tests := []struct {
+		v    string
+		want bool
+	}{
+		{"1.0.0", false},
+		{"1.0.1.dev1", true},
+		{"2.0.0", true},
+		{"2.0.1", false},
+		{"3.0.0", true},
+		{"3.0.0.rc1", false},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.v, func(t *testing.T) {
+			got, err := s.Contains(tt.v)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := s.Contains("1.0"); err == nil {
+		t.Error("got nil, want error")
+	}
+}
+
+func TestAlgebra(t *testing.T) {
+	a := []Interval{Closed("1.0", "2.0"), AtLeast("3.0")}
+	b := []Interval{{&Bound{"1.5", false}, &Bound{"3.5", false}}}
+	tests := []struct {
+		title string
+		op    func(a, b *Set) *Set
+		want  string
+	}{
+		{"Union", (*Set).Union, "[1.0,)"},
+		{"Intersect", (*Set).Intersect, "(1.5,2.0],[3.0,3.5)"},
+		{"Subtract", (*Set).Subtract, "[1.0,1.5],[3.5,)"},
+		{"Reverse subtract", func(a, b *Set) *Set { return b.Subtract(a) }, "(2.0,3.0)"},
+		{"Complement", func(a, b *Set) *Set { return a.Complement() }, "(,1.0),(2.0,3.0)"},
+		{"Double complement", func(a, b *Set) *Set { return a.Complement().Complement() }, "[1.0,2.0],[3.0,)"},
+		{"Union with complement", func(a, b *Set) *Set { return a.Union(a.Complement()) }, "(,)"},
+		{"Intersect with complement", func(a, b *Set) *Set { return a.Intersect(a.Complement()) }, "{}"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got := tt.op(mustSet(t, vercmp.Maven, a...), mustSet(t, vercmp.Maven, b...))
+			if got.String() != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComplementEdges(t *testing.T) {
+	tests := []struct {
+		intervals []Interval
+		want      string
+	}{
+		{nil, "(,)"},
+		{[]Interval{{}}, "{}"},
+		{[]Interval{LessThan("1.0")}, "[1.0,)"},
+		{[]Interval{AtLeast("1.0")}, "(,1.0)"},
+		{[]Interval{Point("1.0")}, "(,1.0),(1.0,)"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			s := mustSet(t, vercmp.Maven, tt.intervals...)
+			if got := s.Complement().String(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if got := s.Complement().IsEmpty(); got != (tt.want == "{}") {
+				t.Errorf("IsEmpty: got %v, want %v", got, !got)
+			}
+		})
+	}
+}
+
+func TestNewFunc(t *testing.T) {
+	byLength := func(a, b string) (int, error) {
+		return len(a) - len(b), nil
+	}
+	s, err := NewFunc(byLength, Closed("aa", "aaaa"))
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if got := s.Complement().String(); got != "(,aa),(aaaa,)" {
+		t.Errorf("got %v, want (,aa),(aaaa,)", got)
+	}
+	if len(s.Intervals()) != 1 {
+		t.Errorf("got %d intervals, want 1", len(s.Intervals()))
+	}
+}
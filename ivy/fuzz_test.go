@@ -0,0 +1,71 @@
+package ivy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wfscheper/vercmp/internal/ordertest"
+)
+
+func FuzzNew(f *testing.F) {
+	for _, v := range revisionOrder {
+		f.Add(v)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		v := New(s)
+		if v.String() != s {
+			t.Errorf("String: got %q, want %q", v, s)
+		}
+		// Splitting at separators and between letters and digits keeps every
+		// other byte, and leaves no letter next to a digit within a part.
+		for i, p := range v.parts {
+			if strings.ContainsAny(p, "._-+") {
+				t.Fatalf("New(%q): part %d is %q", s, i, p)
+			}
+			if letterDigitRe.MatchString(p) || digitLetterRe.MatchString(p) {
+				t.Errorf("New(%q): part %d is %q", s, i, p)
+			}
+		}
+		if got, want := strings.Join(v.parts, ""), separatorRe.ReplaceAllString(s, ""); got != want {
+			t.Errorf("New(%q): parts join to %q, want %q", s, got, want)
+		}
+	})
+}
+
+func FuzzVercmp(f *testing.F) {
+	for i := 0; i+2 < len(revisionOrder); i++ {
+		f.Add(revisionOrder[i], revisionOrder[i+1], revisionOrder[i+2])
+	}
+	f.Add("1.1.3", "1.1.5", "1.01.5")
+	f.Fuzz(func(t *testing.T, a, b, c string) {
+		vercmp := func(a, b string) int { return Vercmp(a, b) }
+		for _, v := range []string{a, b, c} {
+			ordertest.Reflexive(t, vercmp, v)
+		}
+		ordertest.Antisymmetric(t, vercmp, a, b)
+		ordertest.Antisymmetric(t, vercmp, b, c)
+		ordertest.Antisymmetric(t, vercmp, a, c)
+		// Ivy's order is only transitive when no two different parts rank
+		// the same; see canonical.
+		if canonical(a) && canonical(b) && canonical(c) {
+			ordertest.Transitive(t, vercmp, a, b, c)
+		}
+	})
+}
+
+// canonical reports whether each part of revision v is the only spelling of
+// its rank: no number has a leading zero, and no special word has an upper
+// case letter. Otherwise 1.1.3 < 1.1.5 == 1.01.5 == 1.1.3.
+func canonical(v string) bool {
+	for _, p := range New(v).parts {
+		if isNumber(p) && len(p) > 1 && p[0] == '0' {
+			return false
+		}
+		if lower := strings.ToLower(p); lower != p {
+			if _, ok := specials[lower]; ok {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,176 @@
+// Package ivy compares revisions the way Apache Ivy's latest-revision
+// strategy does, and matches Ivy revision patterns.
+//
+// Ivy separates letters from digits with a dot and then splits a revision at
+// '.', '_', '-' and '+'. Numeric parts compare numerically and are newer than
+// any word. Words compare case-sensitively, except for the special words dev,
+// rc and final, which rank in that order; dev is older and the others newer
+// than any other word. When one revision has more parts, an extra numeric
+// part makes it newer and an extra word makes it older.
+//
+// Like Ivy, a comparison ends at the first part that differs as a string,
+// even when the two parts rank the same, as 01 and 1 or RC and rc do. So
+// 1.01.5 and 1.1.3 are equal, while 1.1.3 is older than 1.1.5 and 1.1.5
+// equals 1.01.5: for such revisions the order is not transitive.
+//
+// Although the rules resemble Maven's, Ivy keeps no sublists and applies no
+// qualifier aliases, so the maven package's tokenizer would change the
+// ordering and is not reused here.
+package ivy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/wfscheper/vercmp/cache"
+)
+
+// specials are the ranks of the words with special meaning. Other words rank
+// 0.
+var specials = map[string]int{
+	"dev":   -1,
+	"rc":    1,
+	"final": 2,
+}
+
+var (
+	letterDigitRe = regexp.MustCompile(`([a-zA-Z])(\d)`)
+	digitLetterRe = regexp.MustCompile(`(\d)([a-zA-Z])`)
+	separatorRe   = regexp.MustCompile(`[._\-+]`)
+)
+
+// Version represents a parsed Ivy revision.
+type Version struct {
+	unparsed string
+	parts    []string
+}
+
+// New returns a new Version parsed from the revision string v.
+func New(v string) *Version {
+	s := letterDigitRe.ReplaceAllString(v, "$1.$2")
+	s = digitLetterRe.ReplaceAllString(s, "$1.$2")
+	parts := separatorRe.Split(s, -1)
+	// Like Java's String.split, drop trailing empty parts.
+	for len(parts) > 0 && parts[len(parts)-1] == "" {
+		parts = parts[:len(parts)-1]
+	}
+	return &Version{v, parts}
+}
+
+// String returns the original Ivy revision.
+func (v *Version) String() string {
+	return v.unparsed
+}
+
+// Compare compares v with other, and returns a negative integer if v is older
+// than other, 0 if they are equal, or a positive integer if v is newer than
+// other.
+func (v *Version) Compare(other *Version) int {
+	i := 0
+	for ; i < len(v.parts) && i < len(other.parts); i++ {
+		if v.parts[i] != other.parts[i] {
+			return comparePart(v.parts[i], other.parts[i])
+		}
+	}
+	switch {
+	case i < len(v.parts):
+		if isNumber(v.parts[i]) {
+			return 1
+		}
+		return -1
+	case i < len(other.parts):
+		if isNumber(other.parts[i]) {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// comparePart compares two parts that differ as strings.
+func comparePart(a, b string) int {
+	aNumber, bNumber := isNumber(a), isNumber(b)
+	switch {
+	case aNumber && bNumber:
+		return compareNumbers(a, b)
+	case aNumber:
+		return 1
+	case bNumber:
+		return -1
+	}
+	ra, aSpecial := specials[strings.ToLower(a)]
+	rb, bSpecial := specials[strings.ToLower(b)]
+	if aSpecial || bSpecial {
+		return ra - rb
+	}
+	return strings.Compare(a, b)
+}
+
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// compareNumbers compares two strings of digits numerically, whatever their
+// length.
+func compareNumbers(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
+
+// Equal reports whether v and other are the same revision.
+func (v *Version) Equal(other *Version) bool {
+	return v.Compare(other) == 0
+}
+
+// LessThan reports whether v is older than other.
+func (v *Version) LessThan(other *Version) bool {
+	return v.Compare(other) < 0
+}
+
+// Vercmp compares two Ivy revisions, a and b, and returns a negative integer
+// if a is older than b, 0 if a and b are equal, or a positive integer if a is
+// newer than b. a and b can be either a string or a Version. Vercmp panics if
+// a or b is of any other type.
+func Vercmp(a, b interface{}) int {
+	return toVersion(a).Compare(toVersion(b))
+}
+
+var parseCache = cache.New(0)
+
+// ParseCache returns the cache of parsed revisions that Vercmp uses for strings.
+// It starts disabled; Resize it to enable it.
+func ParseCache() *cache.Cache {
+	return parseCache
+}
+
+// toVersion returns v as a *Version, parsing it if it is a string.
+func toVersion(v interface{}) *Version {
+	switch v := v.(type) {
+	case string:
+		if cached, ok := parseCache.Get(v); ok {
+			return cached.(*Version)
+		}
+		parsed := New(v)
+		parseCache.Add(v, parsed)
+		return parsed
+	case *Version:
+		return v
+	case Version:
+		return &v
+	default:
+		panic(fmt.Sprintf("Unparsable type %T", v))
+	}
+}
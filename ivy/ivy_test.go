@@ -0,0 +1,176 @@
+package ivy
+
+import (
+	"testing"
+
+	"github.com/wfscheper/vercmp/gradle"
+)
+
+// revisionOrder is ordered from oldest to newest.
+var revisionOrder = []string{
+	"0.9",
+	"1.0-dev1",
+	"1.0-dev2",
+	"1.0-ALPHA1",
+	"1.0-alpha1",
+	"1.0-alpha2",
+	"1.0-beta1",
+	"1.0-sp1",
+	"1.0-rc1",
+	"1.0-rc2",
+	"1.0-final",
+	"1.0",
+	"1.0.0",
+	"1.0.1",
+	"1.1",
+	"1.10",
+	"99999999999999999999",
+}
+
+func sign(i int) int {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	}
+	return 0
+}
+
+func TestVercmp(t *testing.T) {
+	for i, a := range revisionOrder {
+		for j, b := range revisionOrder {
+			if got, want := sign(Vercmp(a, b)), sign(i-j); got != want {
+				t.Errorf("Vercmp(%s, %s): got %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestVercmpEqual(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"1.0", "1-0"},
+		{"1.0a1", "1.0.a.1"},
+		{"1_0+a", "1.0-a"},
+		{"1.01", "1.1"},
+		{"1.0-RC1", "1.0-rc1"},
+		{"1.0.", "1.0"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			if got := Vercmp(tt.a, tt.b); got != 0 {
+				t.Errorf("got %d, want 0", got)
+			}
+			if got := Vercmp(tt.b, tt.a); got != 0 {
+				t.Errorf("reversed: got %d, want 0", got)
+			}
+		})
+	}
+}
+
+// TestVercmpFirstDifferentPart checks that, like Ivy, the first part that
+// differs as a string decides the comparison even when it ranks the same.
+func TestVercmpFirstDifferentPart(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"1.01.5", "1.1.3"},
+		{"1.RC.5", "1.rc.3"},
+		{"1.Dev.2", "1.dev.1"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			if got := Vercmp(tt.a, tt.b); got != 0 {
+				t.Errorf("got %d, want 0", got)
+			}
+			if got := Vercmp(tt.b, tt.a); got != 0 {
+				t.Errorf("reversed: got %d, want 0", got)
+			}
+		})
+	}
+}
+
+// TestGradleDisagreements documents pairs that Ivy orders differently from
+// Gradle, whose comparator descends from Ivy's.
+func TestGradleDisagreements(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		// Ivy gives snapshot no special meaning.
+		{"1.0-snapshot", "1.0-rc"},
+		// Ivy ranks sp as an ordinary word.
+		{"1.0-sp", "1.0-rc"},
+		// Ivy only separates ASCII letters from digits, and keeps empty parts.
+		{"1..0", "1.0"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			if got := sign(Vercmp(tt.a, tt.b)); got != -1 {
+				t.Errorf("ivy: got %d, want -1", got)
+			}
+			if got := sign(gradle.Vercmp(tt.a, tt.b)); got == -1 {
+				t.Errorf("gradle: got %d, want a different order", got)
+			}
+		})
+	}
+}
+
+func TestVersionMethods(t *testing.T) {
+	a, b := New("1.0"), New("1.1")
+	if a.Compare(b) >= 0 || b.Compare(a) <= 0 || a.Compare(a) != 0 {
+		t.Error("Compare: got wrong order")
+	}
+	if !a.LessThan(b) || b.LessThan(a) || a.LessThan(a) {
+		t.Error("LessThan: got wrong order")
+	}
+	if !a.Equal(New("1-0")) || a.Equal(b) {
+		t.Error("Equal: got wrong result")
+	}
+	if got := a.String(); got != "1.0" {
+		t.Errorf("String: got %q, want 1.0", got)
+	}
+}
+
+func TestVercmpTypes(t *testing.T) {
+	for _, a := range []interface{}{"1.0", New("1.0"), *New("1.0")} {
+		for _, b := range []interface{}{"1.1", New("1.1"), *New("1.1")} {
+			if got := sign(Vercmp(a, b)); got != -1 {
+				t.Errorf("Vercmp(%#v, %#v): got %d, want -1", a, b, got)
+			}
+		}
+	}
+}
+
+func TestVercmpUnsupportedType(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("got no panic, want panic")
+		}
+	}()
+	Vercmp(1, "1.0")
+}
+
+func TestParseCache(t *testing.T) {
+	c := ParseCache()
+	if got := c.Stats().Capacity; got != 0 {
+		t.Errorf("default capacity: got %d, want a disabled cache", got)
+	}
+	c.Resize(100)
+	defer c.Resize(0)
+	c.Purge()
+	defer c.Purge()
+
+	Vercmp("1.0-rc1", "1.0")
+	Vercmp("1.0-rc1", "1.0")
+	if got := c.Stats(); got.Hits != 2 || got.Misses != 2 || got.Len != 2 {
+		t.Errorf("after comparing the same strings twice: got %+v, want 2 hits, 2 misses and 2 revisions", got)
+	}
+}
@@ -0,0 +1,208 @@
+package ivy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Matcher matches the revisions that an Ivy revision pattern accepts.
+type Matcher interface {
+	// Accepts reports whether the matcher accepts revision v.
+	Accepts(v string) bool
+	// Dynamic reports whether the matcher can accept more than one revision.
+	Dynamic() bool
+	String() string
+}
+
+// Statuses lists Ivy's default module statuses from least to most mature.
+// Revisions ending in SNAPSHOT have status integration and all others
+// release.
+var Statuses = []string{"integration", "milestone", "release"}
+
+// ParseMatcher parses an Ivy revision pattern:
+//
+//	1.0            a single revision
+//	1.0+           any revision that starts with the text before '+'
+//	latest.release any revision whose status is at least the named status
+//	[1.0,2.0[      a range; ']' and '[' exclude a bound, as do '(' and ')'
+func ParseMatcher(s string) (Matcher, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+		return nil, fmt.Errorf("invalid revision %q", s)
+	case strings.HasSuffix(s, "+"):
+		return subRevisionMatcher(s[:len(s)-1]), nil
+	case strings.HasPrefix(s, "latest."):
+		status := s[len("latest."):]
+		for i, known := range Statuses {
+			if status == known {
+				return latestMatcher(i), nil
+			}
+		}
+		return nil, fmt.Errorf("invalid revision %q: unknown status %q", s, status)
+	case strings.ContainsAny(s[:1], "[](") || strings.ContainsAny(s[len(s)-1:], "[])"):
+		return parseRange(s)
+	}
+	return exactMatcher{New(s)}, nil
+}
+
+// exactMatcher accepts a single revision.
+type exactMatcher struct {
+	v *Version
+}
+
+func (m exactMatcher) Accepts(v string) bool {
+	return m.v.unparsed == v
+}
+
+func (m exactMatcher) Dynamic() bool {
+	return false
+}
+
+func (m exactMatcher) String() string {
+	return m.v.String()
+}
+
+// subRevisionMatcher accepts the revisions that start with its text.
+type subRevisionMatcher string
+
+func (m subRevisionMatcher) Accepts(v string) bool {
+	return strings.HasPrefix(v, string(m))
+}
+
+func (m subRevisionMatcher) Dynamic() bool {
+	return true
+}
+
+func (m subRevisionMatcher) String() string {
+	return string(m) + "+"
+}
+
+// latestMatcher accepts the revisions whose status is at least Statuses[m].
+type latestMatcher int
+
+func (m latestMatcher) Accepts(v string) bool {
+	status := len(Statuses) - 1
+	if strings.HasSuffix(strings.ToUpper(v), "SNAPSHOT") {
+		status = 0
+	}
+	return status >= int(m)
+}
+
+func (m latestMatcher) Dynamic() bool {
+	return true
+}
+
+func (m latestMatcher) String() string {
+	return "latest." + Statuses[m]
+}
+
+// rangeMatcher accepts the revisions within its bounds. A nil bound leaves
+// that side of the range open.
+type rangeMatcher struct {
+	lower, upper                   *Version
+	lowerInclusive, upperInclusive bool
+}
+
+func parseRange(s string) (Matcher, error) {
+	if len(s) < 3 || !strings.ContainsAny(s[:1], "[](") || !strings.ContainsAny(s[len(s)-1:], "[])") {
+		return nil, fmt.Errorf("invalid revision range %q", s)
+	}
+	r := rangeMatcher{
+		lowerInclusive: s[0] == '[',
+		upperInclusive: s[len(s)-1] == ']',
+	}
+	inner := s[1 : len(s)-1]
+	comma := strings.IndexByte(inner, ',')
+	if comma < 0 || strings.ContainsAny(inner, "[]()") || strings.Count(inner, ",") > 1 {
+		return nil, fmt.Errorf("invalid revision range %q", s)
+	}
+	if lower := strings.TrimSpace(inner[:comma]); lower != "" {
+		r.lower = New(lower)
+	} else if s[0] != '(' {
+		return nil, fmt.Errorf("invalid revision range %q: unbounded lower bound must use '('", s)
+	}
+	if upper := strings.TrimSpace(inner[comma+1:]); upper != "" {
+		r.upper = New(upper)
+	} else if s[len(s)-1] != ')' {
+		return nil, fmt.Errorf("invalid revision range %q: unbounded upper bound must use ')'", s)
+	}
+	if r.lower == nil && r.upper == nil {
+		return nil, fmt.Errorf("invalid revision range %q: no bounds", s)
+	}
+	if r.lower != nil && r.upper != nil && r.upper.Compare(r.lower) < 0 {
+		return nil, fmt.Errorf("invalid revision range %q: defies revision ordering", s)
+	}
+	return r, nil
+}
+
+func (r rangeMatcher) Accepts(s string) bool {
+	v := New(s)
+	if r.lower != nil {
+		c := v.Compare(r.lower)
+		if c < 0 || c == 0 && !r.lowerInclusive {
+			return false
+		}
+	}
+	if r.upper != nil {
+		c := v.Compare(r.upper)
+		if c > 0 || c == 0 && !r.upperInclusive {
+			return false
+		}
+	}
+	return true
+}
+
+func (r rangeMatcher) Dynamic() bool {
+	return true
+}
+
+// String returns the range in Ivy notation.
+func (r rangeMatcher) String() string {
+	var b strings.Builder
+	switch {
+	case r.lower == nil:
+		b.WriteString("(,")
+	case r.lowerInclusive:
+		b.WriteString("[" + r.lower.String() + ",")
+	default:
+		b.WriteString("]" + r.lower.String() + ",")
+	}
+	switch {
+	case r.upper == nil:
+		b.WriteString(")")
+	case r.upperInclusive:
+		b.WriteString(r.upper.String() + "]")
+	default:
+		b.WriteString(r.upper.String() + "[")
+	}
+	return b.String()
+}
+
+// Strategy orders revisions to find the latest one.
+type Strategy func(a, b string) int
+
+var (
+	// LatestRevision orders revisions as Ivy's latest-revision strategy
+	// does.
+	LatestRevision Strategy = func(a, b string) int { return Vercmp(a, b) }
+	// LatestLexico orders revisions as Ivy's latest-lexico strategy does,
+	// by comparing their text.
+	LatestLexico Strategy = strings.Compare
+)
+
+// Latest returns the latest of revs that m accepts, or "" if m accepts none.
+// A nil m accepts every revision. If several revisions are equally late, the
+// first is returned.
+func (s Strategy) Latest(revs []string, m Matcher) string {
+	latest, found := "", false
+	for _, v := range revs {
+		if m != nil && !m.Accepts(v) {
+			continue
+		}
+		if !found || s(v, latest) > 0 {
+			latest, found = v, true
+		}
+	}
+	return latest
+}
@@ -0,0 +1,132 @@
+package ivy
+
+import "testing"
+
+func TestParseMatcher(t *testing.T) {
+	tests := []struct {
+		in      string
+		dynamic bool
+		accepts []string
+		rejects []string
+	}{
+		{"1.0", false, []string{"1.0"}, []string{"1-0", "1.0.0"}},
+		{"1.0+", true, []string{"1.0", "1.0.1", "1.01"}, []string{"1.1"}},
+		{"1.0.+", true, []string{"1.0.1"}, []string{"1.0", "1.01"}},
+		{"+", true, []string{"0.1"}, nil},
+		{"latest.integration", true, []string{"1.0", "2.0-SNAPSHOT"}, nil},
+		{"latest.release", true, []string{"1.0"}, []string{"2.0-SNAPSHOT"}},
+		{"[1.0,2.0]", true, []string{"1.0", "2.0"}, []string{"0.9", "2.0.1"}},
+		{"[1.0,2.0[", true, []string{"1.0", "2.0-rc1"}, []string{"2.0"}},
+		{"]1.0,2.0]", true, []string{"1.0.1", "2.0"}, []string{"1.0"}},
+		{"]1.0,2.0[", true, []string{"1.5"}, []string{"1.0", "2.0"}},
+		{"(1.0,2.0)", true, []string{"1.5"}, []string{"1.0", "2.0"}},
+		{"[1.0,)", true, []string{"1.0", "9"}, []string{"1.0-rc1"}},
+		{"]1.0,)", true, []string{"1.0.1"}, []string{"1.0"}},
+		{"(,2.0]", true, []string{"2.0"}, []string{"2.0.1"}},
+		{"(,2.0[", true, []string{"1.9"}, []string{"2.0"}},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			m, err := ParseMatcher(tt.in)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got := m.Dynamic(); got != tt.dynamic {
+				t.Errorf("Dynamic: got %v, want %v", got, tt.dynamic)
+			}
+			for _, v := range tt.accepts {
+				if !m.Accepts(v) {
+					t.Errorf("Accepts(%s): got false, want true", v)
+				}
+			}
+			for _, v := range tt.rejects {
+				if m.Accepts(v) {
+					t.Errorf("Accepts(%s): got true, want false", v)
+				}
+			}
+		})
+	}
+}
+
+func TestMatcherString(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"1.0", "1.0"},
+		{"1.0+", "1.0+"},
+		{"latest.milestone", "latest.milestone"},
+		{"[1.0,2.0[", "[1.0,2.0["},
+		{"(1.0, 2.0)", "]1.0,2.0["},
+		{"(,2.0]", "(,2.0]"},
+		{"]1.0,)", "]1.0,)"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			m, err := ParseMatcher(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := m.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMatcherErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"latest.nightly",
+		"[1.0]",
+		"[1.0,2.0",
+		"[,2.0]",
+		"[1.0,]",
+		"(,)",
+		"[1.0,2.0,3.0]",
+		"[2.0,1.0]",
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			if _, err := ParseMatcher(tt); err == nil {
+				t.Error("got nil, want error")
+			}
+		})
+	}
+}
+
+func TestStrategyLatest(t *testing.T) {
+	revs := []string{"1.0", "1.10", "1.9", "2.0-rc1", "2.0-SNAPSHOT", "1.9.0"}
+	tests := []struct {
+		strategy Strategy
+		pattern  string
+		want     string
+	}{
+		{LatestRevision, "", "2.0-rc1"},
+		{LatestRevision, "latest.integration", "2.0-rc1"},
+		{LatestRevision, "latest.release", "2.0-rc1"},
+		{LatestRevision, "1.+", "1.10"},
+		{LatestRevision, "[1.0,1.10[", "1.9.0"},
+		{LatestRevision, "3.0+", ""},
+		{LatestLexico, "", "2.0-rc1"},
+		{LatestLexico, "1.+", "1.9.0"},
+	}
+
+	for _, tt := range tests {
+		var m Matcher
+		if tt.pattern != "" {
+			var err error
+			if m, err = ParseMatcher(tt.pattern); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := tt.strategy.Latest(revs, m); got != tt.want {
+			t.Errorf("Latest(%q): got %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
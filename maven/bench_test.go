@@ -0,0 +1,87 @@
+package maven
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/wfscheper/vercmp/internal/benchtest"
+)
+
+// benchVersions returns n pseudo-random versions in the styles Maven
+// repositories hold, the same ones on every call.
+func benchVersions(n int) []string {
+	suffixes := []string{"", "", "", "-SNAPSHOT", "-alpha-1", "-beta2", "-RC1", "-M3", ".Final", "-sp1", "-jre"}
+	r := rand.New(rand.NewSource(1))
+	vs := make([]string, n)
+	for i := range vs {
+		vs[i] = fmt.Sprintf("%d.%d.%d%s", r.Intn(5), r.Intn(20), r.Intn(50), suffixes[r.Intn(len(suffixes))])
+	}
+	return vs
+}
+
+func BenchmarkParse(b *testing.B) {
+	vs := benchVersions(1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		New(vs[i%len(vs)])
+	}
+}
+
+func BenchmarkComparePreparsed(b *testing.B) {
+	vs := NewVersions(benchVersions(1001))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		j := i % (len(vs) - 1)
+		vs[j].Compare(vs[j+1])
+	}
+}
+
+func BenchmarkCompareStrings(b *testing.B) {
+	vs := benchVersions(1001)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		j := i % (len(vs) - 1)
+		Vercmp(vs[j], vs[j+1])
+	}
+}
+
+func BenchmarkCompareStringsCached(b *testing.B) {
+	defer benchtest.EnableCache(ParseCache(), 4096)()
+	BenchmarkCompareStrings(b)
+}
+
+func BenchmarkSort10k(b *testing.B) {
+	vs := benchVersions(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sort(vs)
+	}
+}
+
+func BenchmarkSort10kPreparsed(b *testing.B) {
+	vs := NewVersions(benchVersions(10000))
+	work := make(Versions, len(vs))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(work, vs)
+		sort.Sort(work)
+	}
+}
+
+// TestAllocs guards the allocations of the benchmarked operations.
+func TestAllocs(t *testing.T) {
+	a, b := New("1.2.3-milestone.1"), New("1.2.3-milestone.2")
+	benchtest.Allocs(t, "New", 28, func() { New("1.2.3-milestone.1") })
+	benchtest.Allocs(t, "New release", 3, func() { New("1.2.3") })
+	benchtest.Allocs(t, "Compare", 0, func() { a.Compare(b) })
+	benchtest.Allocs(t, "Vercmp strings", 57, func() { Vercmp("1.2.3-milestone.1", "1.2.3-milestone.2") })
+	defer benchtest.EnableCache(ParseCache(), 4096)()
+	benchtest.Allocs(t, "Vercmp strings, cached", 3, func() { Vercmp("1.2.3-milestone.1", "1.2.3-milestone.2") })
+}
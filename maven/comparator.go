@@ -0,0 +1,123 @@
+package maven
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Comparator parses and compares versions with a configurable ordering of
+// qualifiers. New, Vercmp and the other package-level functions use a
+// Comparator with Maven's default qualifiers and aliases.
+//
+// A Version remembers the Comparator that parsed it, and its Compare and
+// SortKey methods order it the way that Comparator does.
+type Comparator struct {
+	qualifiers []string
+	ranks      map[string]int
+	aliases    map[string]string
+	profile    Profile
+}
+
+var defaultComparator = &Comparator{
+	qualifiers: qualifiers[:],
+	ranks:      rankQualifiers(qualifiers[:]),
+	aliases:    aliases,
+	profile:    DefaultProfile,
+}
+
+// maxQualifiers is the most qualifiers a Comparator may rank, as sort keys
+// hold a qualifier's rank in a byte.
+const maxQualifiers = 255
+
+// NewComparator returns a Comparator that ranks qualifiers in the order they
+// are given, from oldest to newest, and replaces each alias with its value
+// when parsing. The empty qualifier marks a release and must be among
+// qualifiers. Unknown qualifiers are newer than any of qualifiers and compare
+// alphabetically, as they do in Maven. Qualifiers and aliases are matched
+// without regard to case. The Comparator follows DefaultProfile.
+func NewComparator(qualifiers []string, aliases map[string]string) (*Comparator, error) {
+	if len(qualifiers) > maxQualifiers {
+		return nil, fmt.Errorf("invalid qualifiers: %d qualifiers, want at most %d", len(qualifiers), maxQualifiers)
+	}
+	lower := make([]string, len(qualifiers))
+	for i, q := range qualifiers {
+		lower[i] = strings.ToLower(q)
+		if err := checkQualifier(lower[i]); err != nil {
+			return nil, err
+		}
+	}
+	ranks := rankQualifiers(lower)
+	if len(ranks) != len(lower) {
+		return nil, fmt.Errorf("invalid qualifiers %q: duplicate qualifier", qualifiers)
+	}
+	if _, ok := ranks[""]; !ok {
+		return nil, fmt.Errorf("invalid qualifiers %q: missing the empty release qualifier", qualifiers)
+	}
+
+	c := &Comparator{qualifiers: lower, ranks: ranks, aliases: make(map[string]string, len(aliases)), profile: DefaultProfile}
+	for alias, q := range aliases {
+		alias = strings.ToLower(alias)
+		if alias == "" {
+			return nil, fmt.Errorf("invalid alias %q for %q", alias, q)
+		}
+		if err := checkQualifier(alias); err != nil {
+			return nil, err
+		}
+		if _, ok := ranks[alias]; ok {
+			return nil, fmt.Errorf("invalid alias %q: it is also a qualifier", alias)
+		}
+		c.aliases[alias] = strings.ToLower(q)
+	}
+	return c, nil
+}
+
+// checkQualifier returns an error if q can never be parsed as a qualifier,
+// because parsing splits versions at separators and digits.
+func checkQualifier(q string) error {
+	if strings.ContainsAny(q, ".-0123456789") {
+		return fmt.Errorf("invalid qualifier %q: contains a separator or digit", q)
+	}
+	if q != strings.TrimSpace(q) {
+		return fmt.Errorf("invalid qualifier %q: contains surrounding space", q)
+	}
+	return nil
+}
+
+func rankQualifiers(qualifiers []string) map[string]int {
+	ranks := make(map[string]int, len(qualifiers))
+	for i, q := range qualifiers {
+		ranks[q] = i
+	}
+	return ranks
+}
+
+// DefaultQualifiers returns Maven's qualifiers from oldest to newest, for use
+// as the basis of a custom Comparator.
+func DefaultQualifiers() []string {
+	return append([]string(nil), qualifiers[:]...)
+}
+
+// DefaultAliases returns Maven's qualifier aliases, for use as the basis of a
+// custom Comparator.
+func DefaultAliases() map[string]string {
+	m := make(map[string]string, len(aliases))
+	for k, v := range aliases {
+		m[k] = v
+	}
+	return m
+}
+
+// Compare compares a with b using c's qualifiers and profile, whichever
+// Comparator parsed them, and returns a negative integer if a is older than
+// b, 0 if they are equal, or a positive integer if a is newer than b.
+func (c *Comparator) Compare(a, b *Version) int {
+	ra, rb := cursor{s: a.encoded}, cursor{s: b.encoded}
+	return c.compareLists(&ra, &rb)
+}
+
+// Vercmp compares two versions, a and b, like the package-level Vercmp but
+// using c. Strings are parsed with c, through ParseCache only if c is the
+// Comparator of DefaultProfile.
+func (c *Comparator) Vercmp(a, b interface{}) int {
+	return c.Compare(c.toVersion(a), c.toVersion(b))
+}
@@ -0,0 +1,207 @@
+package maven
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func customComparator(t *testing.T) *Comparator {
+	t.Helper()
+	qualifiers := []string{"alpha", "beta", "milestone", "EA", "preview", "rc", "snapshot", "", "sp", "hotfix"}
+	aliases := DefaultAliases()
+	aliases["pre"] = "preview"
+	c, err := NewComparator(qualifiers, aliases)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestComparator(t *testing.T) {
+	c := customComparator(t)
+	// Ordered from oldest to newest.
+	ordered := []string{
+		"1-alpha-1",
+		"1-beta",
+		"1-m2",
+		"1-ea",
+		"1-ea-2",
+		"1-preview",
+		"1-pre-2",
+		"1-rc1",
+		"1-cr2",
+		"1-SNAPSHOT",
+		"1",
+		"1-sp",
+		"1-hotfix",
+		"1-hotfix2",
+		"1-abc",
+		"1-zzz",
+		"1.1",
+	}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			if got, want := sign(c.Vercmp(a, b)), sign(i-j); got != want {
+				t.Errorf("Vercmp(%s, %s): got %d, want %d", a, b, got, want)
+			}
+		}
+	}
+
+	if got := c.Compare(c.Parse("1-pre"), c.Parse("1.0-PREVIEW")); got != 0 {
+		t.Errorf("alias: got %d, want 0", got)
+	}
+}
+
+func TestComparatorDefaultUnchanged(t *testing.T) {
+	_ = customComparator(t)
+	// The default comparator treats the custom qualifiers as unknown, which
+	// sort alphabetically after sp.
+	ordered := []string{"1-SNAPSHOT", "1", "1-sp", "1-ea", "1-hotfix", "1-pre", "1-preview"}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			if got, want := sign(Vercmp(a, b)), sign(i-j); got != want {
+				t.Errorf("Vercmp(%s, %s): got %d, want %d", a, b, got, want)
+			}
+		}
+	}
+
+	c, err := NewComparator(DefaultQualifiers(), DefaultAliases())
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := mavenVersions(t)
+	for _, a := range all {
+		for _, b := range all {
+			if got, want := sign(c.Vercmp(a, b)), sign(Vercmp(a, b)); got != want {
+				t.Errorf("Vercmp(%s, %s): got %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestComparatorManyQualifiers(t *testing.T) {
+	// More than nine qualifiers must still rank by position, not by text.
+	qualifiers := []string{"q", "r", "s", "t", "u", "v", "w", "x", "y", "z", "", "aa", "ab"}
+	c, err := NewComparator(qualifiers, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, a := range qualifiers {
+		for j, b := range qualifiers {
+			if got, want := sign(c.Vercmp("1-"+a, "1-"+b)), sign(i-j); got != want {
+				t.Errorf("Vercmp(1-%s, 1-%s): got %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	q := DefaultQualifiers()
+	q[0] = "changed"
+	if got := DefaultQualifiers()[0]; got != "alpha" {
+		t.Errorf("DefaultQualifiers: got %q, want alpha", got)
+	}
+	a := DefaultAliases()
+	a["ga"] = "changed"
+	if got := DefaultAliases(); !reflect.DeepEqual(got, map[string]string{"ga": "", "final": "", "cr": "rc"}) {
+		t.Errorf("DefaultAliases: got %v", got)
+	}
+}
+
+func TestNewComparatorErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		qualifiers []string
+		aliases    map[string]string
+	}{
+		{"missing release", []string{"alpha", "beta"}, nil},
+		{"duplicate", []string{"alpha", "", "ALPHA"}, nil},
+		{"digit", []string{"alpha1", ""}, nil},
+		{"separator", []string{"pre-view", ""}, nil},
+		{"space", []string{" alpha", ""}, nil},
+		{"empty alias", []string{""}, map[string]string{"": "alpha"}},
+		{"alias digit", []string{""}, map[string]string{"a1": "alpha"}},
+		{"alias is qualifier", []string{"alpha", ""}, map[string]string{"alpha": ""}},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewComparator(tt.qualifiers, tt.aliases); err == nil {
+				t.Error("got nil, want error")
+			}
+		})
+	}
+}
+
+func TestComparatorParsedVersions(t *testing.T) {
+	c := customComparator(t)
+	// The default comparator orders each pair the other way round.
+	tests := []struct{ low, high string }{
+		{"1-ea", "1"},
+		{"1-hotfix", "1-abc"},
+		{"1-preview", "1-sp"},
+	}
+	for _, tt := range tests {
+		low, high := c.Parse(tt.low), c.Parse(tt.high)
+		if New(tt.low).Compare(New(tt.high)) <= 0 {
+			t.Fatalf("default: %s is not newer than %s", tt.low, tt.high)
+		}
+		if !low.LessThan(high) || high.Compare(low) <= 0 {
+			t.Errorf("Compare(%s, %s): got %d, want < 0", tt.low, tt.high, low.Compare(high))
+		}
+		if got := Vercmp(low, tt.high); got >= 0 {
+			t.Errorf("Vercmp(%s, %s): got %d, want < 0", tt.low, tt.high, got)
+		}
+		if bytes.Compare(low.SortKey(), high.SortKey()) >= 0 {
+			t.Errorf("SortKey: %s does not sort before %s", tt.low, tt.high)
+		}
+	}
+
+	key := c.Parse("1.0-PRE-2").SortKey()
+	v, err := c.FromSortKey(key)
+	if err != nil {
+		t.Fatalf("FromSortKey: got %v, want nil", err)
+	}
+	if v.String() != "1-preview-2" || !bytes.Equal(v.SortKey(), key) {
+		t.Errorf("FromSortKey: got %q with key %x, want 1-preview-2 with key %x", v, v.SortKey(), key)
+	}
+
+	u := *c.Parse("")
+	if err := u.UnmarshalText([]byte("1-ea")); err != nil {
+		t.Fatal(err)
+	}
+	if got := u.Compare(c.Parse("1")); got >= 0 {
+		t.Errorf("UnmarshalText: got %d comparing 1-ea with 1, want < 0", got)
+	}
+}
+
+func TestComparatorVersionsAsKeys(t *testing.T) {
+	if *Maven30.Comparator().Parse("1.0") != *Maven30.Comparator().Parse("1.0") {
+		t.Error("versions parsed by the same profile differ")
+	}
+	if *Maven36.Comparator().Parse("1.0") != *New("1.0") {
+		t.Error("versions parsed by the default profile and New differ")
+	}
+	if *Maven39.Comparator().Parse("1.0") == *New("1.0") {
+		t.Error("versions parsed by different profiles are the same")
+	}
+	if (Version{}) != *New("") {
+		t.Error("the zero Version differs from New(\"\")")
+	}
+}
+
+func TestComparatorTooManyQualifiers(t *testing.T) {
+	qualifiers := []string{""}
+	for len(qualifiers) <= maxQualifiers {
+		qualifiers = append(qualifiers, fmt.Sprintf("q%c%c", 'a'+len(qualifiers)/26, 'a'+len(qualifiers)%26))
+	}
+	if _, err := NewComparator(qualifiers, nil); err == nil {
+		t.Errorf("%d qualifiers: got nil, want error", len(qualifiers))
+	}
+	if _, err := NewComparator(qualifiers[:maxQualifiers], nil); err != nil {
+		t.Errorf("%d qualifiers: got %v, want nil", maxQualifiers, err)
+	}
+}
@@ -0,0 +1,211 @@
+package maven
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// A corpus file in testdata/comparable holds sequences of versions separated
+// by blank lines. Each line of a sequence holds versions that are equal to
+// each other and newer than the versions on the line before. Lines starting
+// with # are comments. A line such as "@profiles 3.6 3.9" limits the
+// sequences that follow it to those profiles, and "@isolated" leaves them out
+// of the checks that compare versions across sequences.
+type sequence struct {
+	file     string
+	pos      string
+	profiles []Profile
+	isolated bool
+	lines    [][]string
+}
+
+var corpusProfiles = map[string]Profile{
+	"3.0": Maven30,
+	"3.6": Maven36,
+	"3.9": Maven39,
+}
+
+func loadCorpus(t *testing.T) []sequence {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join("testdata", "comparable", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no corpus files")
+	}
+
+	var seqs []sequence
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		active := profiles
+		isolated := false
+		inSequence := false
+		scanner := bufio.NewScanner(f)
+		for n := 1; scanner.Scan(); n++ {
+			line := strings.TrimSpace(scanner.Text())
+			switch {
+			case line == "":
+				inSequence = false
+			case strings.HasPrefix(line, "#"):
+			case strings.HasPrefix(line, "@profiles"):
+				inSequence = false
+				active = nil
+				for _, name := range strings.Fields(line)[1:] {
+					p, ok := corpusProfiles[name]
+					if !ok {
+						t.Fatalf("%s:%d: unknown profile %q", file, n, name)
+					}
+					active = append(active, p)
+				}
+			case line == "@isolated":
+				inSequence = false
+				isolated = true
+			case strings.HasPrefix(line, "@"):
+				t.Fatalf("%s:%d: unknown directive %q", file, n, line)
+			default:
+				if !inSequence {
+					seqs = append(seqs, sequence{
+						file:     filepath.Base(file),
+						pos:      fmt.Sprintf("%s:%d", file, n),
+						profiles: active,
+						isolated: isolated,
+					})
+					inSequence = true
+				}
+				seq := &seqs[len(seqs)-1]
+				seq.lines = append(seq.lines, strings.Fields(line))
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+	}
+	return seqs
+}
+
+// corpusVersions returns the versions of the corpus file name in the order
+// they appear, leaving out comments and directives.
+func corpusVersions(tb testing.TB, name string) []string {
+	tb.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "comparable", name))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	var vs []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "@") {
+			continue
+		}
+		vs = append(vs, strings.Fields(line)...)
+	}
+	return vs
+}
+
+// mavenVersions returns the versions of Maven's own ordering tests, which
+// are in qualifiers.txt and numbers.txt.
+func mavenVersions(tb testing.TB) []string {
+	return append(corpusVersions(tb, "qualifiers.txt"), corpusVersions(tb, "numbers.txt")...)
+}
+
+func TestCorpusOrder(t *testing.T) {
+	for _, seq := range loadCorpus(t) {
+		for _, p := range seq.profiles {
+			c := p.Comparator()
+			for i, low := range seq.lines {
+				for j, high := range seq.lines {
+					for _, a := range low {
+						for _, b := range high {
+							if got, want := sign(c.Vercmp(a, b)), sign(i-j); got != want {
+								t.Errorf("%s: %s: Vercmp(%s, %s): got %d, want %d", seq.pos, p, a, b, got, want)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestCorpusTotalOrder checks that, for each profile, Vercmp orders all the
+// versions of the corpus that apply to it consistently, including versions
+// from different sequences: the order must be antisymmetric and transitive.
+func TestCorpusTotalOrder(t *testing.T) {
+	seqs := loadCorpus(t)
+	for _, p := range profiles {
+		c := p.Comparator()
+		var vs []*Version
+		seen := make(map[string]bool)
+		for _, seq := range seqs {
+			if seq.isolated || !hasProfile(seq.profiles, p) {
+				continue
+			}
+			for _, line := range seq.lines {
+				for _, s := range line {
+					if !seen[s] {
+						seen[s] = true
+						vs = append(vs, c.Parse(s))
+					}
+				}
+			}
+		}
+
+		r := make([][]int, len(vs))
+		for i, a := range vs {
+			r[i] = make([]int, len(vs))
+			for j, b := range vs {
+				r[i][j] = sign(c.Compare(a, b))
+			}
+		}
+
+		errors := 0
+		report := func(format string, args ...interface{}) {
+			if errors++; errors <= 10 {
+				t.Errorf("%s: "+format, append([]interface{}{p}, args...)...)
+			}
+		}
+		for i := range vs {
+			for j := range vs {
+				if r[i][j] != -r[j][i] {
+					report("antisymmetry: %s vs %s is %d, but %s vs %s is %d", vs[i], vs[j], r[i][j], vs[j], vs[i], r[j][i])
+				}
+				if r[i][j] > 0 {
+					continue
+				}
+				for k := range vs {
+					if r[j][k] > 0 {
+						continue
+					}
+					want := 0
+					if r[i][j] < 0 || r[j][k] < 0 {
+						want = -1
+					}
+					if r[i][k] != want {
+						report("transitivity: %s <= %s <= %s, but %s vs %s is %d", vs[i], vs[j], vs[k], vs[i], vs[k], r[i][k])
+					}
+				}
+			}
+		}
+		if errors > 10 {
+			t.Errorf("%s: %d more errors", p, errors-10)
+		}
+	}
+}
+
+func hasProfile(ps []Profile, p Profile) bool {
+	for _, q := range ps {
+		if q == p {
+			return true
+		}
+	}
+	return false
+}
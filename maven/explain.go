@@ -0,0 +1,228 @@
+package maven
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Explanation describes why two versions compare the way they do.
+type Explanation struct {
+	A, B string
+	// ATree and BTree are the parsed items of A and B. Nested lists hold
+	// the items that follow a "-" or a transition between letters and
+	// digits.
+	ATree, BTree string
+	// Result is negative if A is older than B, 0 if they are equal, and
+	// positive if A is newer than B.
+	Result int
+	// Position is the path of list indexes to the first item that decided
+	// the comparison, or nil if the versions are equal.
+	Position []int
+	// Reason explains the decision in words.
+	Reason string
+}
+
+// String returns a multi-line report of e.
+func (e *Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "a: %s -> %s\n", e.A, e.ATree)
+	fmt.Fprintf(&b, "b: %s -> %s\n", e.B, e.BTree)
+	switch {
+	case e.Result < 0:
+		fmt.Fprintf(&b, "%s < %s\n", e.A, e.B)
+	case e.Result > 0:
+		fmt.Fprintf(&b, "%s > %s\n", e.A, e.B)
+	default:
+		fmt.Fprintf(&b, "%s == %s\n", e.A, e.B)
+	}
+	if e.Position != nil {
+		pos := make([]string, len(e.Position))
+		for i, p := range e.Position {
+			pos[i] = strconv.Itoa(p)
+		}
+		fmt.Fprintf(&b, "at item %s: %s\n", strings.Join(pos, "."), e.Reason)
+	} else {
+		fmt.Fprintf(&b, "%s\n", e.Reason)
+	}
+	return b.String()
+}
+
+// Explain compares a and b like Vercmp and explains the result.
+func Explain(a, b string) *Explanation {
+	return defaultComparator.Explain(a, b)
+}
+
+// Explain compares a and b like c.Vercmp and explains the result.
+func (c *Comparator) Explain(a, b string) *Explanation {
+	va, vb := c.Parse(a), c.Parse(b)
+	e := &Explanation{
+		A:      a,
+		B:      b,
+		ATree:  formatItem(va.tree()),
+		BTree:  formatItem(vb.tree()),
+		Reason: "all items are equal once trailing zeros and release qualifiers are dropped",
+	}
+	ra, rb := cursor{s: va.encoded}, cursor{s: vb.encoded}
+	if r, pos, reason := c.explainLists(&ra, &rb, []int{}); r != 0 {
+		e.Result, e.Position, e.Reason = r, pos, reason
+	}
+	return e
+}
+
+// explainLists walks two encoded lists like compareLists, and also returns
+// the position of the deciding items and the reason for the decision. Items
+// are only decoded to describe the ones that decide the comparison.
+func (c *Comparator) explainLists(a, b *cursor, pos []int) (int, []int, string) {
+	// Siblings share pos's backing array, so it is copied once an item
+	// decides the comparison.
+	for i := 0; ; i++ {
+		var r int
+		var p []int
+		var reason string
+		switch aEnd, bEnd := a.atEnd(), b.atEnd(); {
+		case aEnd && bEnd:
+			a.skipEnd()
+			b.skipEnd()
+			return 0, nil, ""
+		case aEnd:
+			r, p, reason = c.explainMissing(b, append(pos, i))
+			r = -r
+		case bEnd:
+			r, p, reason = c.explainMissing(a, append(pos, i))
+		case a.s[a.i] == tagList && b.s[b.i] == tagList:
+			a.i++
+			b.i++
+			r, p, reason = c.explainLists(a, b, append(pos, i))
+		default:
+			x, y := *a, *b
+			if r = c.compareItems(a, b); r != 0 {
+				p, reason = append([]int(nil), append(pos, i)...), c.reason(x.item(), y.item(), r)
+			}
+		}
+		if r != 0 {
+			return r, p, reason
+		}
+	}
+}
+
+// explainMissing walks the next item of r like compareMissing, and explains
+// the result like explainLists.
+func (c *Comparator) explainMissing(r *cursor, pos []int) (int, []int, string) {
+	if r.s[r.i] != tagList {
+		x := *r
+		if result := c.compareMissing(r); result != 0 {
+			return result, append([]int(nil), pos...), c.reason(x.item(), nil, result)
+		}
+		return 0, nil, ""
+	}
+	r.i++
+	for i := 0; !r.atEnd(); i++ {
+		if i > 0 && c.profile != Maven39 {
+			// Only the first item of a list is compared with a missing item.
+			r.skip()
+			continue
+		}
+		if result, p, reason := c.explainMissing(r, append(pos, i)); result != 0 {
+			return result, p, reason
+		}
+	}
+	r.skipEnd()
+	return 0, nil, ""
+}
+
+// reason explains why item a compares to item b as r, which is not 0.
+func (c *Comparator) reason(a, b interface{}, r int) string {
+	order := "newer"
+	if r < 0 {
+		order = "older"
+	}
+	var rule string
+	switch a := a.(type) {
+	case int, *big.Int:
+		switch b.(type) {
+		case int, *big.Int:
+			rule = "numbers compare numerically"
+		case nil:
+			rule = "a missing item counts as 0"
+		default:
+			rule = "numbers are newer than qualifiers and lists"
+		}
+	case string:
+		switch b := b.(type) {
+		case string:
+			rule = c.qualifierRule(a, b)
+		case nil:
+			rule = "a missing item counts as the release qualifier; " + c.qualifierRule(a, "")
+		default:
+			rule = "qualifiers are older than numbers and lists"
+		}
+	case []interface{}:
+		switch b.(type) {
+		case int, *big.Int:
+			rule = "lists are older than numbers"
+		case string:
+			rule = "lists are newer than qualifiers"
+		}
+	}
+	return fmt.Sprintf("%s is %s than %s: %s", c.describe(a), order, c.describe(b), rule)
+}
+
+func (c *Comparator) qualifierRule(a, b string) string {
+	_, aKnown := c.ranks[a]
+	_, bKnown := c.ranks[b]
+	switch {
+	case aKnown && bKnown:
+		ordered := make([]string, len(c.ranks))
+		for q, rank := range c.ranks {
+			ordered[rank] = strconv.Quote(q)
+		}
+		return "known qualifiers rank " + strings.Join(ordered, " < ")
+	case aKnown || bKnown:
+		return "unknown qualifiers are newer than known ones"
+	}
+	return "unknown qualifiers compare alphabetically"
+}
+
+func (c *Comparator) describe(item interface{}) string {
+	switch item := item.(type) {
+	case int, *big.Int:
+		return fmt.Sprint("number ", item)
+	case string:
+		if _, ok := c.ranks[item]; ok {
+			return "qualifier " + strconv.Quote(item)
+		}
+		return "unknown qualifier " + strconv.Quote(item)
+	case []interface{}:
+		return "list " + formatItem(item)
+	}
+	return "a missing item"
+}
+
+// formatItem renders a parsed item, quoting qualifiers.
+func formatItem(item interface{}) string {
+	var b strings.Builder
+	writeItem(&b, item)
+	return b.String()
+}
+
+func writeItem(b *strings.Builder, item interface{}) {
+	switch item := item.(type) {
+	case int, *big.Int:
+		fmt.Fprint(b, item)
+	case string:
+		b.WriteString(strconv.Quote(item))
+	case []interface{}:
+		b.WriteByte('[')
+		for i, sub := range item {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			writeItem(b, sub)
+		}
+		b.WriteByte(']')
+	default:
+		fmt.Fprint(b, item)
+	}
+}
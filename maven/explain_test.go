@@ -0,0 +1,91 @@
+package maven
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		result   int
+		position []int
+		reason   string
+	}{
+		{"1.0-rc1", "1.0.cr1", -1, []int{1}, "list [\"rc\", [1]] is older than number 0: lists are older than numbers"},
+		{"1-alpha", "1-beta", -1, []int{1, 0}, "qualifier \"alpha\" is older than qualifier \"beta\": known qualifiers rank"},
+		{"1-abc", "1-sp", 1, []int{1, 0}, "unknown qualifiers are newer than known ones"},
+		{"1-abc", "1-def", -1, []int{1, 0}, "unknown qualifiers compare alphabetically"},
+		{"1.1", "1.0.1", 1, []int{1}, "number 1 is newer than number 0: numbers compare numerically"},
+		{"12345678901234567890", "2", 1, []int{0}, "number 12345678901234567890 is newer than number 2: numbers compare numerically"},
+		{"1-rc", "1", -1, []int{1, 0}, "a missing item counts as the release qualifier"},
+		{"1", "1-rc", 1, []int{1, 0}, "qualifier \"rc\" is older than a missing item"},
+		{"1.1", "1", 1, []int{1}, "a missing item counts as 0"},
+		{"1-1", "1.1", -1, []int{1}, "lists are older than numbers"},
+		{"1-1", "1.a", 1, []int{1}, "lists are newer than qualifiers"},
+		{"1.0", "1", 0, nil, "all items are equal"},
+		{"1-0.1", "1", 0, nil, "all items are equal"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			e := Explain(tt.a, tt.b)
+			if got := sign(e.Result); got != tt.result {
+				t.Errorf("Result: got %d, want %d", got, tt.result)
+			}
+			if !reflect.DeepEqual(e.Position, tt.position) {
+				t.Errorf("Position: got %v, want %v", e.Position, tt.position)
+			}
+			if !strings.Contains(e.Reason, tt.reason) {
+				t.Errorf("Reason: got %q, want it to contain %q", e.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestExplainMatchesVercmp(t *testing.T) {
+	all := append([]string{"1-0.1", "1.0-release", "1.0.0.x1", "1-ga-1"}, mavenVersions(t)...)
+	for _, p := range profiles {
+		c := p.Comparator()
+		for _, a := range all {
+			for _, b := range all {
+				e := c.Explain(a, b)
+				if got, want := sign(e.Result), sign(c.Vercmp(a, b)); got != want {
+					t.Errorf("%s: Explain(%s, %s): got %d, want %d", p, a, b, got, want)
+				}
+				if (e.Position == nil) != (e.Result == 0) {
+					t.Errorf("%s: Explain(%s, %s): got position %v for result %d", p, a, b, e.Position, e.Result)
+				}
+			}
+		}
+	}
+}
+
+func TestExplainProfile(t *testing.T) {
+	e := Maven39.Comparator().Explain("1-0.1", "1")
+	if e.Result <= 0 || !reflect.DeepEqual(e.Position, []int{1, 1}) {
+		t.Errorf("got result %d at %v, want > 0 at [1 1]", e.Result, e.Position)
+	}
+}
+
+func TestExplanationString(t *testing.T) {
+	want := `a: 1.0-rc1 -> [1, ["rc", [1]]]
+b: 1.0.cr1 -> [1, 0, "rc", [1]]
+1.0-rc1 < 1.0.cr1
+at item 1: list ["rc", [1]] is older than number 0: lists are older than numbers
+`
+	if got := Explain("1.0-rc1", "1.0.cr1").String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	want = `a: 1.0 -> [1]
+b: 1-ga -> [1]
+1.0 == 1-ga
+all items are equal once trailing zeros and release qualifiers are dropped
+`
+	if got := Explain("1.0", "1-ga").String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
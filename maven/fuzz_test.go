@@ -0,0 +1,102 @@
+package maven
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/wfscheper/vercmp/internal/ordertest"
+)
+
+func FuzzNew(f *testing.F) {
+	for _, v := range append([]string{"", "-1", ".1", "1..1", "1-ga-1"}, mavenVersions(f)...) {
+		f.Add(v)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, p := range profiles {
+			p.Comparator().Parse(s).Items()
+		}
+		v := New(s)
+		if v.String() != s {
+			t.Errorf("String: got %q, want %q", v, s)
+		}
+		_ = fmt.Sprintf("%+v", v)
+		if r := Vercmp(v, s); r != 0 {
+			t.Errorf("Vercmp(%q, itself): got %d, want 0", s, r)
+		}
+		got, err := FromSortKey(v.SortKey())
+		if err != nil {
+			t.Fatalf("FromSortKey(%q): got %v, want nil", s, err)
+		}
+		if !got.Equal(v) {
+			t.Errorf("FromSortKey(%q): got %+v, want %+v", s, got, v)
+		}
+	})
+}
+
+func FuzzVercmp(f *testing.F) {
+	for _, list := range [][]string{corpusVersions(f, "qualifiers.txt"), corpusVersions(f, "numbers.txt")} {
+		for i := range list[:len(list)-2] {
+			f.Add(list[i], list[i+1], list[i+2])
+		}
+	}
+	f.Add("1-alpha", "1", "1.x")
+	f.Add("1", "1-x", "1.0.alpha")
+	f.Fuzz(func(t *testing.T, a, b, c string) {
+		for _, p := range profiles {
+			cmp := p.Comparator()
+			vercmp := func(a, b string) int { return cmp.Vercmp(a, b) }
+			for _, v := range []string{a, b, c} {
+				ordertest.Reflexive(t, vercmp, v)
+			}
+			ordertest.Antisymmetric(t, vercmp, a, b)
+			ordertest.Antisymmetric(t, vercmp, b, c)
+			ordertest.Antisymmetric(t, vercmp, a, c)
+			// Maven itself is not transitive for every version, so only
+			// check the versions it orders; see ordered for the cycles.
+			if ordered(cmp.Parse(a)) && ordered(cmp.Parse(b)) && ordered(cmp.Parse(c)) {
+				ordertest.Transitive(t, vercmp, a, b, c)
+			}
+			if got, want := sign(cmp.Explain(a, b).Result), sign(vercmp(a, b)); got != want {
+				t.Errorf("%s: Explain(%q, %q): got %d, want %d", p, a, b, got, want)
+			}
+		}
+	})
+}
+
+// ordered reports whether v is one of the versions that Maven orders
+// transitively. Maven's order has cycles, such as 1-alpha < 1 < 1.x < 1-alpha
+// and 1 < 1-x < 1.0.alpha < 1, that involve a qualifier after a "." or a zero
+// or empty item before the end of a list. So v may only hold a qualifier as
+// the first item of a nested list, and no zero other than as its first item.
+func ordered(v *Version) bool {
+	items := v.Items()
+	if len(items) > 0 && items[0].Kind == IntItem {
+		items = items[1:]
+	}
+	return orderedItems(items)
+}
+
+func orderedItems(items []Item) bool {
+	for _, item := range items {
+		switch item.Kind {
+		case IntItem:
+			if item.Big == nil && item.Int <= 0 {
+				return false
+			}
+		case StringItem:
+			return false
+		case ListItem:
+			if len(item.Items) == 0 {
+				return false
+			}
+			rest := item.Items
+			if first := rest[0]; first.Kind == StringItem && first.Qualifier != "" {
+				rest = rest[1:]
+			}
+			if !orderedItems(rest) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,145 @@
+package maven
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ItemKind is the kind of an Item.
+type ItemKind int
+
+// Item kinds, named after the items of Maven's ComparableVersion.
+const (
+	IntItem ItemKind = iota + 1
+	StringItem
+	ListItem
+)
+
+func (k ItemKind) String() string {
+	switch k {
+	case IntItem:
+		return "int"
+	case StringItem:
+		return "string"
+	case ListItem:
+		return "list"
+	}
+	return "ItemKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Item is a token of a parsed Version.
+type Item struct {
+	// Kind is the kind of the item.
+	Kind ItemKind
+	// Int is the value of an IntItem that fits in an int.
+	Int int
+	// Big is the value of an IntItem that is too large for an int, and nil
+	// otherwise.
+	Big *big.Int
+	// Qualifier is the lower case, de-aliased value of a StringItem.
+	Qualifier string
+	// Items are the tokens of a ListItem, which follow a "-" or a
+	// transition between letters and digits.
+	Items []Item
+}
+
+// Items returns the tokens of m after normalization. Changing them does not
+// affect m.
+func (m *Version) Items() []Item {
+	return toItems(m.tree())
+}
+
+func toItems(list []interface{}) []Item {
+	items := make([]Item, len(list))
+	for i, item := range list {
+		switch item := item.(type) {
+		case int:
+			items[i] = Item{Kind: IntItem, Int: item}
+		case *big.Int:
+			items[i] = Item{Kind: IntItem, Big: new(big.Int).Set(item)}
+		case string:
+			items[i] = Item{Kind: StringItem, Qualifier: item}
+		case []interface{}:
+			items[i] = Item{Kind: ListItem, Items: toItems(item)}
+		}
+	}
+	return items
+}
+
+// String returns the canonical form of i, as Maven's ComparableVersion
+// renders it: the items of a list are separated by "." and a nested list is
+// introduced by "-".
+func (i Item) String() string {
+	var b strings.Builder
+	i.writeString(&b)
+	return b.String()
+}
+
+func (i Item) writeString(b *strings.Builder) {
+	switch i.Kind {
+	case IntItem:
+		if i.Big != nil {
+			b.WriteString(i.Big.String())
+		} else {
+			b.WriteString(strconv.Itoa(i.Int))
+		}
+	case StringItem:
+		b.WriteString(i.Qualifier)
+	case ListItem:
+		start := b.Len()
+		for _, item := range i.Items {
+			if b.Len() > start {
+				if item.Kind == ListItem {
+					b.WriteByte('-')
+				} else {
+					b.WriteByte('.')
+				}
+			}
+			item.writeString(b)
+		}
+	}
+}
+
+// writeList renders i with lists in brackets, like ComparableVersion's
+// toListString.
+func (i Item) writeList(b *strings.Builder) {
+	if i.Kind != ListItem {
+		i.writeString(b)
+		return
+	}
+	b.WriteByte('[')
+	for j, item := range i.Items {
+		if j > 0 {
+			b.WriteString(", ")
+		}
+		item.writeList(b)
+	}
+	b.WriteByte(']')
+}
+
+// Format implements fmt.Formatter. The %v, %s and %q verbs print the original
+// version string, and %+v prints the version, its canonical form and its
+// tokens, as ComparableVersion's main method does:
+//
+//	1-1.foo-bar1baz-.1 -> 1-1.foo-bar-1-baz-0.1; tokens: [1, [1, foo, [bar, [1, [baz, [0, 1]]]]]]
+func (m Version) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		root := Item{Kind: ListItem, Items: m.Items()}
+		var b strings.Builder
+		b.WriteString(m.unparsed)
+		b.WriteString(" -> ")
+		root.writeString(&b)
+		b.WriteString("; tokens: ")
+		root.writeList(&b)
+		fmt.Fprint(f, b.String())
+	case verb == 'v' || verb == 's':
+		fmt.Fprint(f, m.unparsed)
+	case verb == 'q':
+		fmt.Fprint(f, strconv.Quote(m.unparsed))
+	default:
+		fmt.Fprintf(f, "%%!%c(maven.Version=%s)", verb, m.unparsed)
+	}
+}
@@ -0,0 +1,80 @@
+package maven
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestItems(t *testing.T) {
+	got := New("1.0-RC1").Items()
+	want := []Item{
+		{Kind: IntItem, Int: 1},
+		{Kind: ListItem, Items: []Item{
+			{Kind: StringItem, Qualifier: "rc"},
+			{Kind: ListItem, Items: []Item{{Kind: IntItem, Int: 1}}},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+
+	got[1].Items[0].Qualifier = "alpha"
+	if again := New("1.0-RC1").Items(); !reflect.DeepEqual(again, want) {
+		t.Errorf("modifying Items changed the version: got %#v", again)
+	}
+
+	big := New("12345678901234567890.1").Items()
+	if len(big) != 2 || big[0].Kind != IntItem || big[0].Big == nil || big[0].String() != "12345678901234567890" {
+		t.Errorf("large number: got %#v", big)
+	}
+
+	if got := New("").Items(); len(got) != 0 {
+		t.Errorf("empty version: got %#v, want no items", got)
+	}
+}
+
+func TestItemKindString(t *testing.T) {
+	tests := []struct {
+		kind ItemKind
+		want string
+	}{
+		{IntItem, "int"},
+		{StringItem, "string"},
+		{ListItem, "list"},
+		{ItemKind(0), "ItemKind(0)"},
+	}
+	for _, tt := range tests {
+		if got := tt.kind.String(); got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		format, version, want string
+	}{
+		{"%+v", "1-1.foo-bar1baz-.1", "1-1.foo-bar1baz-.1 -> 1-1.foo-bar-1-baz-0.1; tokens: [1, [1, foo, [bar, [1, [baz, [0, 1]]]]]]"},
+		{"%+v", "1.0.0-GA", "1.0.0-GA -> 1; tokens: [1]"},
+		{"%+v", "1.ga.1", "1.ga.1 -> 1..1; tokens: [1, , 1]"},
+		{"%+v", "", " -> ; tokens: []"},
+		{"%v", "1.0-RC1", "1.0-RC1"},
+		{"%s", "1.0-RC1", "1.0-RC1"},
+		{"%q", "1.0-RC1", `"1.0-RC1"`},
+		{"%d", "1.0", "%!d(maven.Version=1.0)"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.format+" "+tt.version, func(t *testing.T) {
+			v := New(tt.version)
+			if got := fmt.Sprintf(tt.format, v); got != tt.want {
+				t.Errorf("pointer: got %q, want %q", got, tt.want)
+			}
+			if got := fmt.Sprintf(tt.format, *v); got != tt.want {
+				t.Errorf("value: got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package maven
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Sort key tags. The tags are ordered so that comparing two keys bytewise
+// matches comparing the items they encode. An item that is missing from the
+// shorter of two lists compares like keyEnd, so items that are older than a
+// missing item get a tag below keyEnd and items that are newer get a tag
+// above it.
+const (
+	keyLowString  byte = 0x01 // a qualifier older than a release
+	keyLowList    byte = 0x02 // a list older than a release
+	keyEnd        byte = 0x03 // the end of a list
+	keyHighString byte = 0x04 // a release, service pack or unknown qualifier
+	keyHighList   byte = 0x05 // a list newer than a release
+	keyInt        byte = 0x06 // an integer
+)
+
+// SortKey returns a byte string whose lexicographic order matches the order of
+// Vercmp, so that versions can be ordered by stores that only compare bytes.
+// Qualifiers are ranked by the Comparator that parsed m, so keys of versions
+// parsed by different Comparators do not sort together.
+//
+// Vercmp is not a total order for a handful of unusual versions, such as ones
+// with zero or empty items in the middle ("1.0.alpha") or lists that begin
+// with a zero ("1-0.1"). For those the key settles on one consistent order.
+func (m *Version) SortKey() []byte {
+	return m.comparator().appendListKey(make([]byte, 0, 32), m.tree())
+}
+
+// FromSortKey returns the Version encoded by key. Since the key only holds the
+// parsed version, the Version's string is its canonical form: "1.0-RC1" and
+// "1-cr-1" both decode to "1-rc-1".
+func FromSortKey(key []byte) (*Version, error) {
+	return defaultComparator.FromSortKey(key)
+}
+
+// FromSortKey returns the Version encoded by key, which must be the SortKey
+// of a Version parsed by c, like the package-level FromSortKey.
+func (c *Comparator) FromSortKey(key []byte) (*Version, error) {
+	parsed, rest, err := c.decodeListKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, errInvalidKey
+	}
+	s := c.canonical(parsed)
+	if strings.TrimRightFunc(s, unicode.IsSpace) != s {
+		// A qualifier can end in white space, as in 1-x 0, which Parse
+		// would trim. A trailing "." keeps it without adding an item.
+		s += "."
+	}
+	v := c.Parse(s)
+	if !bytes.Equal(v.SortKey(), key) {
+		return nil, errInvalidKey
+	}
+	return v, nil
+}
+
+func (c *Comparator) appendListKey(b []byte, list []interface{}) []byte {
+	for _, item := range list {
+		switch item := item.(type) {
+		case int:
+			b = appendIntKey(append(b, keyInt), item)
+		case *big.Int:
+			b = appendBigKey(append(b, keyInt), item)
+		case string:
+			rank := c.qualifierRank(item)
+			if rank < c.ranks[""] {
+				b = append(b, keyLowString, byte(rank))
+			} else {
+				b = append(b, keyHighString, byte(rank))
+			}
+			if rank == len(c.qualifiers) {
+				b = appendStringKey(b, item)
+			}
+		case []interface{}:
+			if c.listSign(item) < 0 {
+				b = append(b, keyLowList)
+			} else {
+				b = append(b, keyHighList)
+			}
+			b = c.appendListKey(b, item)
+		}
+	}
+	return append(b, keyEnd)
+}
+
+// appendIntKey appends the length of i in bytes followed by i in big-endian
+// order, so that longer integers sort after shorter ones.
+func appendIntKey(b []byte, i int) []byte {
+	n := 0
+	for v := i; v > 0; v >>= 8 {
+		n++
+	}
+	b = append(b, byte(n))
+	for shift := 8 * (n - 1); shift >= 0; shift -= 8 {
+		b = append(b, byte(i>>uint(shift)))
+	}
+	return b
+}
+
+// appendBigKey appends i like appendIntKey does. A length of 255 bytes or more
+// is written as 0xff followed by the length in four big-endian bytes.
+func appendBigKey(b []byte, i *big.Int) []byte {
+	digits := i.Bytes()
+	if n := len(digits); n < 0xff {
+		b = append(b, byte(n))
+	} else {
+		b = append(b, 0xff, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+	return append(b, digits...)
+}
+
+// appendStringKey appends s terminated by 0x00 0x01. Zero bytes within s are
+// escaped as 0x00 0xff so that they sort after the terminator.
+func appendStringKey(b []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		if s[i] == 0 {
+			b = append(b, 0, 0xff)
+		} else {
+			b = append(b, s[i])
+		}
+	}
+	return append(b, 0, 1)
+}
+
+// listSign returns the sign of comparing list with a missing item, looking
+// past any leading items that are equal to a missing item.
+func (c *Comparator) listSign(list []interface{}) int {
+	for _, item := range list {
+		var sign int
+		switch item := item.(type) {
+		case int:
+			if item > 0 {
+				sign = 1
+			}
+		case *big.Int:
+			sign = 1
+		case string:
+			sign = c.qualifierRank(item) - c.ranks[""]
+		case []interface{}:
+			sign = c.listSign(item)
+		}
+		if sign != 0 {
+			return sign
+		}
+	}
+	return 0
+}
+
+// qualifierRank returns the rank of s, or the number of qualifiers if s is not
+// a known qualifier.
+func (c *Comparator) qualifierRank(s string) int {
+	if rank, ok := c.ranks[s]; ok {
+		return rank
+	}
+	return len(c.qualifiers)
+}
+
+var errInvalidKey = errors.New("invalid maven sort key")
+
+func (c *Comparator) decodeListKey(b []byte) ([]interface{}, []byte, error) {
+	list := make([]interface{}, 0)
+	for len(b) > 0 {
+		tag := b[0]
+		b = b[1:]
+		switch tag {
+		case keyEnd:
+			return list, b, nil
+		case keyInt:
+			if len(b) == 0 {
+				return nil, nil, errInvalidKey
+			}
+			n := int(b[0])
+			b = b[1:]
+			if n == 0xff {
+				if len(b) < 4 {
+					return nil, nil, errInvalidKey
+				}
+				n, b = int(b[0])<<24|int(b[1])<<16|int(b[2])<<8|int(b[3]), b[4:]
+			}
+			if n < 0 || len(b) < n {
+				return nil, nil, errInvalidKey
+			}
+			i := new(big.Int).SetBytes(b[:n])
+			if i.IsInt64() && i.Int64() <= math.MaxInt {
+				list = append(list, int(i.Int64()))
+			} else {
+				list = append(list, i)
+			}
+			b = b[n:]
+		case keyLowString, keyHighString:
+			if len(b) == 0 || int(b[0]) > len(c.qualifiers) {
+				return nil, nil, errInvalidKey
+			}
+			rank := int(b[0])
+			b = b[1:]
+			if rank < len(c.qualifiers) {
+				list = append(list, c.qualifiers[rank])
+				continue
+			}
+			str, rest, err := decodeStringKey(b)
+			if err != nil {
+				return nil, nil, err
+			}
+			list = append(list, str)
+			b = rest
+		case keyLowList, keyHighList:
+			var sub []interface{}
+			var err error
+			if sub, b, err = c.decodeListKey(b); err != nil {
+				return nil, nil, err
+			}
+			list = append(list, sub)
+		default:
+			return nil, nil, errInvalidKey
+		}
+	}
+	return nil, nil, errInvalidKey
+}
+
+func decodeStringKey(b []byte) (string, []byte, error) {
+	var s []byte
+	for i := 0; i < len(b); i++ {
+		if b[i] != 0 {
+			s = append(s, b[i])
+			continue
+		}
+		if i+1 == len(b) {
+			break
+		}
+		switch b[i+1] {
+		case 1:
+			return string(s), b[i+2:], nil
+		case 0xff:
+			s = append(s, 0)
+			i++
+		default:
+			return "", nil, errInvalidKey
+		}
+	}
+	return "", nil, errInvalidKey
+}
+
+// canonical returns a version string that c parses to list. Items are
+// separated by "." and a nested list is introduced by "-".
+func (c *Comparator) canonical(list []interface{}) string {
+	var b strings.Builder
+	c.writeCanonical(&b, list)
+	return b.String()
+}
+
+func (c *Comparator) writeCanonical(b *strings.Builder, list []interface{}) {
+	for i, item := range list {
+		switch item := item.(type) {
+		case int:
+			if i > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(strconv.Itoa(item))
+		case *big.Int:
+			if i > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(item.String())
+		case string:
+			if i > 0 {
+				b.WriteByte('.')
+			}
+			if item == "" {
+				item = c.releaseAlias()
+			}
+			b.WriteString(item)
+		case []interface{}:
+			b.WriteByte('-')
+			c.writeCanonical(b, item)
+		}
+	}
+}
+
+// releaseAlias returns an alias that c parses to the empty release qualifier,
+// preferring "ga", or "" if c has none.
+func (c *Comparator) releaseAlias() string {
+	if q, ok := c.aliases["ga"]; ok && q == "" {
+		return "ga"
+	}
+	alias := ""
+	for a, q := range c.aliases {
+		if q == "" && (alias == "" || a < alias) {
+			alias = a
+		}
+	}
+	return alias
+}
@@ -0,0 +1,120 @@
+package maven
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSortKeyQualifiers(t *testing.T) {
+	ordered := []string{"1-alpha", "1-beta", "1-milestone", "1-rc", "1-snapshot",
+		"1", "1-sp", "1-abc", "1-xyz"}
+
+	t.Parallel()
+	for i, low := range ordered[:len(ordered)-1] {
+		high := ordered[i+1]
+		t.Run(low+" < "+high, func(t *testing.T) {
+			if bytes.Compare(New(low).SortKey(), New(high).SortKey()) >= 0 {
+				t.Error("got false")
+			}
+		})
+	}
+}
+
+func TestSortKeyOrder(t *testing.T) {
+	corpus := mavenVersions(t)
+	corpus = append(corpus, "1.0-alpha-1", "1.0-alpha-1-SNAPSHOT", "1.0-SNAPSHOT",
+		"1.0.1", "2.0.1-klm", "2.0.1-xyz", "2.0.1-123", "1ga", "1final", "1cr",
+		"1a1", "1-alpha-1", "1.0-0", "1..1", "-1", ".1", "1-1-1", "",
+		"9223372036854775807", "9223372036854775808", "12345678901234567890",
+		strings.Repeat("9", 700), "1"+strings.Repeat("0", 700), "1-"+strings.Repeat("7", 30))
+
+	t.Parallel()
+	for _, a := range corpus {
+		for _, b := range corpus {
+			want := sign(Vercmp(a, b))
+			got := bytes.Compare(New(a).SortKey(), New(b).SortKey())
+			if got != want {
+				t.Errorf("%q vs %q: got %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestFromSortKey(t *testing.T) {
+	tests := []struct {
+		v, want string
+	}{
+		{"", ""},
+		{"1.0", "1"},
+		{"1.0-RC1", "1-rc-1"},
+		{"1-cr-1", "1-rc-1"},
+		{"1.0-alpha-1-SNAPSHOT", "1-alpha-1-snapshot"},
+		{"1.2.3-xyz.4", "1.2.3-xyz.4"},
+		{"-1", "-1"},
+		{".1", "0.1"},
+		{"1-ga-1", "1--1"},
+		{"1.ga.1", "1.ga.1"},
+		{"1.0.x", "1.0.x"},
+		{"99999999999999999999", "99999999999999999999"},
+		{"0" + strings.Repeat("9", 700), strings.Repeat("9", 700)},
+		{"1-a\x00b", "1-a\x00b"},
+		{"1-x 0", "1-x ."},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.v, func(t *testing.T) {
+			key := New(tt.v).SortKey()
+			got, err := FromSortKey(key)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if !bytes.Equal(got.SortKey(), key) {
+				t.Errorf("got key %x, want %x", got.SortKey(), key)
+			}
+		})
+	}
+}
+
+func TestFromSortKeyInvalid(t *testing.T) {
+	tests := []struct {
+		title string
+		key   []byte
+	}{
+		{"Empty", []byte{}},
+		{"Missing end", []byte{keyInt, 1, 1}},
+		{"Trailing bytes", []byte{keyEnd, keyEnd}},
+		{"Unknown tag", []byte{0x42, keyEnd}},
+		{"Short integer", []byte{keyInt, 2, 1}},
+		{"Trailing zero", []byte{keyInt, 1, 1, keyInt, 0, keyEnd}},
+		{"Unknown rank", []byte{keyLowString, 42, keyEnd}},
+		{"Wrong string tag", []byte{keyInt, 1, 1, keyHighString, 0, keyEnd}},
+		{"Unterminated string", []byte{keyHighString, 7, 'x', keyEnd}},
+		{"Bad escape", []byte{keyHighString, 7, 'x', 0, 2, keyEnd}},
+		{"Known qualifier as string", []byte{keyHighString, 7, 'a', 'l', 'p', 'h', 'a', 0, 1, keyEnd}},
+		{"Unclosed list", []byte{keyInt, 1, 1, keyHighList, keyInt, 1, 1, keyEnd}},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if v, err := FromSortKey(tt.key); err == nil {
+				t.Errorf("got %q, want error", v)
+			}
+		})
+	}
+}
+
+func sign(i int) int {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	}
+	return 0
+}
@@ -2,8 +2,11 @@ package maven
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
+
+	"github.com/wfscheper/vercmp/cache"
 )
 
 var aliases = map[string]string{
@@ -14,14 +17,27 @@ var aliases = map[string]string{
 
 var qualifiers = [7]string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
 
-// Version repersents a parsed Maven 3 version string.
+// Version repersents a parsed Maven 3 version string. Versions are comparable,
+// so they can be map keys, but == and map keys go by the original string: two
+// Versions are == only when they were parsed from the same string by the same
+// Comparator. New("1.0") and New("1") are Equal, yet they are different map
+// keys. To key a map by the version itself, use string(v.SortKey()).
 type Version struct {
 	unparsed string
-	parsed   []interface{}
+	encoded  string
+	// c is the Comparator that parsed the version, or nil for the default
+	// one, so that the zero Version equals New("").
+	c *Comparator
 }
 
 // New returns a new Version parsed from the version string v.
 func New(v string) *Version {
+	return defaultComparator.Parse(v)
+}
+
+// Parse returns a new Version parsed from the version string v, applying c's
+// aliases.
+func (c *Comparator) Parse(v string) *Version {
 	parsed := make([]interface{}, 0, 10)
 	currentSlice := &parsed
 	start := 0
@@ -34,7 +50,7 @@ func New(v string) *Version {
 				*currentSlice = append(*currentSlice, 0)
 			} else {
 				*currentSlice = append(*currentSlice,
-					parseBuffer(buf[start:idx], false))
+					c.parseBuffer(buf[start:idx], false))
 			}
 			start = idx + 1
 		} else if ch == '-' {
@@ -42,33 +58,69 @@ func New(v string) *Version {
 				*currentSlice = append(*currentSlice, 0)
 			} else {
 				*currentSlice = append(*currentSlice,
-					parseBuffer(buf[start:idx], false))
+					c.parseBuffer(buf[start:idx], false))
 			}
 			start = idx + 1
-			currentSlice = newSlice(currentSlice)
+			if c.profile != Maven30 {
+				currentSlice = newSlice(currentSlice)
+			} else if isDigit {
+				// Maven 3.0 only separates 1-1 from 1.1.
+				normalize30(currentSlice)
+				if idx+1 < len(buf) && '0' <= buf[idx+1] && buf[idx+1] <= '9' {
+					currentSlice = newSlice(currentSlice)
+				}
+			}
 		} else if _, err := strconv.Atoi(string(ch)); err == nil {
 			if !isDigit && idx > start {
+				if c.profile == Maven39 && len(*currentSlice) > 0 {
+					// Maven 3.9 parses 1.0.0.x1 like 1.0.0-x1.
+					currentSlice = newSlice(currentSlice)
+				}
 				*currentSlice = append(*currentSlice,
-					parseBuffer(buf[start:idx], true))
-				currentSlice = newSlice(currentSlice)
+					c.parseBuffer(buf[start:idx], true))
+				if c.profile != Maven30 {
+					currentSlice = newSlice(currentSlice)
+				}
 				start = idx
 			}
 			isDigit = true
 		} else {
 			if isDigit && idx > start {
 				*currentSlice = append(*currentSlice,
-					parseBuffer(buf[start:idx], false))
-				currentSlice = newSlice(currentSlice)
+					c.parseBuffer(buf[start:idx], false))
+				if c.profile != Maven30 {
+					currentSlice = newSlice(currentSlice)
+				}
 				start = idx
 			}
 			isDigit = false
 		}
 	}
 	if len(buf) > start {
-		*currentSlice = append(*currentSlice, parseBuffer(buf[start:], false))
+		if c.profile == Maven39 && !isDigit && len(*currentSlice) > 0 {
+			// And it parses a trailing 2.0.x like 2.0-x.
+			currentSlice = newSlice(currentSlice)
+		}
+		*currentSlice = append(*currentSlice, c.parseBuffer(buf[start:], false))
+	}
+	if c.profile == Maven30 {
+		normalize30(&parsed)
+	} else {
+		normalize(&parsed)
+	}
+	m := &Version{unparsed: v, encoded: encodeTree(parsed, len(buf))}
+	if c != defaultComparator {
+		m.c = c
+	}
+	return m
+}
+
+// comparator returns the Comparator that parsed m.
+func (m *Version) comparator() *Comparator {
+	if m.c == nil {
+		return defaultComparator
 	}
-	normalize(&parsed)
-	return &Version{v, parsed}
+	return m.c
 }
 
 // String returns the oringal Maven version.
@@ -76,106 +128,107 @@ func (m *Version) String() string {
 	return m.unparsed
 }
 
+// MarshalText implements encoding.TextMarshaler. The version is marshalled as
+// the original version string.
+func (m Version) MarshalText() ([]byte, error) {
+	return []byte(m.unparsed), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed with
+// the Comparator that parsed m, which is the default one for the zero
+// Version.
+func (m *Version) UnmarshalText(text []byte) error {
+	*m = *m.comparator().Parse(string(text))
+	return nil
+}
+
+// Compare compares m with other using the Comparator that parsed m, and
+// returns a negative integer if m is older than other, 0 if they are equal,
+// or a positive integer if m is newer than other.
+func (m *Version) Compare(other *Version) int {
+	return m.comparator().Compare(m, other)
+}
+
+// Equal reports whether m and other are the same version.
+func (m *Version) Equal(other *Version) bool {
+	return m.Compare(other) == 0
+}
+
+// LessThan reports whether m is older than other.
+func (m *Version) LessThan(other *Version) bool {
+	return m.Compare(other) < 0
+}
+
 // Vercmp compares two Maven 3 versions, a and b, and returns 1 if a is newer
 // than b, 0 if a and b are equal, or -1 if a is older than b. a and b an be
-// either a string or a Version.
+// either a string or a Version. Vercmp panics if a or b is of any other type.
+//
+// If a or b is a Version, the versions are compared like a Version's Compare
+// method does, with the Comparator that parsed it.
 func Vercmp(a, b interface{}) int {
-	var aVer, bVer *Version
-	switch a := a.(type) {
-	case string:
-		aVer = New(a)
-	case *Version:
-		aVer = a
-	case Version:
-		aVer = &a
-	}
-	switch b := b.(type) {
-	case string:
-		bVer = New(b)
-	case *Version:
-		bVer = b
-	case Version:
-		bVer = &b
-	}
-	return compareSlice(aVer.parsed, bVer.parsed)
+	return comparatorOf(a, b).Vercmp(a, b)
 }
 
-func compare(a, b interface{}) int {
-	switch a := a.(type) {
-	case int:
-		return compareInt(a, b)
-	case string:
-		return compareString(a, b)
-	case []interface{}:
-		return compareSlice(a, b)
-	default:
-		return 1
+// comparatorOf returns the Comparator that parsed a, or else b, or the default
+// Comparator if neither is a Version.
+func comparatorOf(a, b interface{}) *Comparator {
+	for _, v := range [2]interface{}{a, b} {
+		switch v := v.(type) {
+		case *Version:
+			return v.comparator()
+		case Version:
+			return v.comparator()
+		}
 	}
+	return defaultComparator
 }
 
-func compareInt(a int, b interface{}) int {
-	switch b := b.(type) {
-	default:
-		panic(fmt.Sprintf("Unkown type %t", b))
-	case int:
-		return a - b
-	case string, []interface{}, nil:
-		return a
-	}
+var parseCache = cache.New(0)
+
+// ParseCache returns the cache of parsed versions that Vercmp uses for
+// strings. It starts disabled; Resize it to enable it. Only Vercmp and the
+// Comparator of DefaultProfile use it: the Comparators of other profiles, and
+// those built with NewComparator, always parse strings anew.
+func ParseCache() *cache.Cache {
+	return parseCache
 }
 
-func compareSlice(a []interface{}, b interface{}) int {
-	switch b := b.(type) {
-	default:
-		panic(fmt.Sprintf("Unkown type %t", b))
-	case nil:
-		if len(a) == 0 {
-			return 0
-		}
-		return compare(a[0], b)
-	case int:
-		return -1
+// toVersion returns v as a *Version, parsing it with c if it is a string.
+func (c *Comparator) toVersion(v interface{}) *Version {
+	switch v := v.(type) {
 	case string:
-		return 1
-	case []interface{}:
-		var result int
-		for _, pair := range zip(a, b) {
-			if pair.Left == nil {
-				if pair.Right == nil {
-					result = 0
-				} else {
-					result = -1 * compare(pair.Right, pair.Left)
-				}
-			} else {
-				result = compare(pair.Left, pair.Right)
-			}
-			if result != 0 {
-				return result
-			}
+		if c != defaultComparator {
+			return c.Parse(v)
+		}
+		if cached, ok := parseCache.Get(v); ok {
+			return cached.(*Version)
 		}
-		return 0
+		parsed := c.Parse(v)
+		parseCache.Add(v, parsed)
+		return parsed
+	case *Version:
+		return v
+	case Version:
+		return &v
+	default:
+		panic(fmt.Sprintf("Unparsable type %T", v))
 	}
 }
 
-func compareString(a string, b interface{}) int {
-	switch b := b.(type) {
-	default:
-		panic(fmt.Sprintf("Unkown type %t", b))
-	case int, []interface{}:
+// compareQualifiers compares two qualifiers: known ones by rank, unknown ones
+// alphabetically, and known ones are older than unknown ones.
+func (c *Comparator) compareQualifiers(a, b string) int {
+	aRank, aKnown := c.ranks[a]
+	bRank, bKnown := c.ranks[b]
+	switch {
+	case aKnown && bKnown:
+		return aRank - bRank
+	case aKnown:
 		return -1
-	case nil:
-		return compareString(a, "")
-	case string:
-		aValue := stringValue(a)
-		bValue := stringValue(b)
-		if aValue < bValue {
-			return -1
-		} else if aValue == bValue {
-			return 0
-		} else {
-			return 1
-		}
+	case bKnown:
+		return 1
 	}
+	return strings.Compare(a, b)
 }
 
 // appendSlicePtr appends to a slice poitner
@@ -185,10 +238,19 @@ func appendSlicePtr(sPtr *[]interface{}, elem ...interface{}) {
 	*sPtr = s
 }
 
-// parseBuffer determines if the string b is an integer or a string
+// parseBuffer determines if the string b is an integer or a string. Numbers
+// too large for an int are returned as a *big.Int.
 func parseBuffer(b string, digitFollows bool) interface{} {
+	return defaultComparator.parseBuffer(b, digitFollows)
+}
+
+func (c *Comparator) parseBuffer(b string, digitFollows bool) interface{} {
 	if r, err := strconv.Atoi(b); err == nil {
 		return r
+	} else if err.(*strconv.NumError).Err == strconv.ErrRange {
+		if r, ok := new(big.Int).SetString(b, 10); ok {
+			return r
+		}
 	}
 	if digitFollows && len(b) == 1 {
 		switch b {
@@ -200,7 +262,7 @@ func parseBuffer(b string, digitFollows bool) interface{} {
 			b = "milestone"
 		}
 	}
-	if r, ok := aliases[b]; ok {
+	if r, ok := c.aliases[b]; ok {
 		return r
 	}
 	return b
@@ -233,6 +295,10 @@ func normalize(sPtr *[]interface{}) {
 				*sPtr = s
 				return
 			}
+		case *big.Int:
+			// A number too large for an int is never 0.
+			*sPtr = s
+			return
 		case *[]interface{}:
 			normalize(e)
 			if len(*e) == 0 {
@@ -245,41 +311,40 @@ func normalize(sPtr *[]interface{}) {
 	*sPtr = s
 }
 
-func stringValue(s string) string {
-	for idx, q := range qualifiers {
-		if s == q {
-			return strconv.FormatInt(int64(idx+1), 10)
-		}
-	}
-	return fmt.Sprintf("%d-%s", len(qualifiers), s)
-}
-
-type interfaceTuple struct {
-	Left, Right interface{}
-}
-
-func zip(a, b []interface{}) []interfaceTuple {
-	var r []interfaceTuple
-
-	aLen := len(a)
-	bLen := len(b)
-
-	if aLen < bLen {
-		r = make([]interfaceTuple, bLen)
-		for i, e := range a {
-			r[i] = interfaceTuple{Left: e, Right: b[i]}
-		}
-		for i, e := range b[aLen:] {
-			r[aLen+i] = interfaceTuple{Left: nil, Right: e}
-		}
-	} else {
-		r = make([]interfaceTuple, aLen)
-		for i, e := range b {
-			r[i] = interfaceTuple{Left: a[i], Right: e}
-		}
-		for i, e := range a[bLen:] {
-			r[bLen+i] = interfaceTuple{Left: e, Right: nil}
+// normalize30 removes null items from the end of s the way Maven 3.0 does,
+// stopping at the first item that is not null. In Maven 3.0 a nested list can
+// only be the last item of its parent.
+func normalize30(sPtr *[]interface{}) {
+	s := *sPtr
+	for i := len(s) - 1; i >= 0; i-- {
+		switch e := s[i].(type) {
+		case int:
+			if e != 0 {
+				*sPtr = s
+				return
+			}
+		case string:
+			if e != "" {
+				*sPtr = s
+				return
+			}
+		case *big.Int:
+			*sPtr = s
+			return
+		case *[]interface{}:
+			normalize30(e)
+			if len(*e) > 0 {
+				s[i] = *e
+				*sPtr = s
+				return
+			}
+		case []interface{}:
+			if len(e) > 0 {
+				*sPtr = s
+				return
+			}
 		}
+		s = s[:i]
 	}
-	return r
+	*sPtr = s
 }
@@ -1,72 +1,13 @@
 package maven
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"reflect"
 	"testing"
 )
 
-func TestZip(t *testing.T) {
-	tests := []struct {
-		title string
-		a, b  []interface{}
-		want  []interfaceTuple
-	}{
-		{
-			"One element each",
-			[]interface{}{0},
-			[]interface{}{1},
-			[]interfaceTuple{interfaceTuple{0, 1}},
-		},
-		{
-			"Two elements each",
-			[]interface{}{0, "0"},
-			[]interface{}{"1", 1},
-			[]interfaceTuple{interfaceTuple{0, "1"}, interfaceTuple{"0", 1}},
-		},
-		{
-			"One and two elements",
-			[]interface{}{0},
-			[]interface{}{"1", 1},
-			[]interfaceTuple{interfaceTuple{0, "1"}, interfaceTuple{nil, 1}},
-		},
-		{
-			"Two and one elements",
-			[]interface{}{0, "0"},
-			[]interface{}{"1"},
-			[]interfaceTuple{interfaceTuple{0, "1"}, interfaceTuple{"0", nil}},
-		},
-		{
-			"Empty slices",
-			[]interface{}{},
-			[]interface{}{},
-			[]interfaceTuple{},
-		},
-		{
-			"Empty slice a",
-			[]interface{}{},
-			[]interface{}{0, 1},
-			[]interfaceTuple{interfaceTuple{nil, 0}, interfaceTuple{nil, 1}},
-		},
-		{
-			"Empty slice b",
-			[]interface{}{0, 1},
-			[]interface{}{},
-			[]interfaceTuple{interfaceTuple{0, nil}, interfaceTuple{1, nil}},
-		},
-	}
-
-	t.Parallel()
-	for _, tt := range tests {
-		t.Run(tt.title, func(t *testing.T) {
-			got := zip(tt.a, tt.b)
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("got %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestParseBuffer(t *testing.T) {
 	tests := []struct {
 		b            string
@@ -149,6 +90,10 @@ func TestNormalize(t *testing.T) {
 			[]interface{}{1, &[]interface{}{2, &[]interface{}{}}},
 			[]interface{}{1, []interface{}{2}},
 		},
+		{
+			[]interface{}{1, 0, big.NewInt(0).Lsh(big.NewInt(1), 70)},
+			[]interface{}{1, 0, big.NewInt(0).Lsh(big.NewInt(1), 70)},
+		},
 	}
 
 	t.Parallel()
@@ -176,79 +121,85 @@ func TestNewSlice(t *testing.T) {
 	}
 }
 
+// parsedVersion is a version string and the items it parses to.
+type parsedVersion struct {
+	unparsed string
+	parsed   []interface{}
+}
+
 func TestNewVersion(t *testing.T) {
-	tests := []Version{
+	tests := []parsedVersion{
 		// weird versions
-		Version{".1", []interface{}{0, 1}},
-		Version{"-1", []interface{}{[]interface{}{1}}},
+		parsedVersion{".1", []interface{}{0, 1}},
+		parsedVersion{"-1", []interface{}{[]interface{}{1}}},
 		// test some major.minor.tiny parsing
-		Version{"1", []interface{}{1}},
-		Version{"1.0", []interface{}{1}},
-		Version{"1.0.0", []interface{}{1}},
-		Version{"1.0.0.0", []interface{}{1}},
-		Version{"11", []interface{}{11}},
-		Version{"11.0", []interface{}{11}},
-		Version{"1-1", []interface{}{1, []interface{}{1}}},
-		Version{"1-1-1", []interface{}{1, []interface{}{1, []interface{}{1}}}},
-		Version{" 1 ", []interface{}{1}},
+		parsedVersion{"1", []interface{}{1}},
+		parsedVersion{"1.0", []interface{}{1}},
+		parsedVersion{"1.0.0", []interface{}{1}},
+		parsedVersion{"1.0.0.0", []interface{}{1}},
+		parsedVersion{"11", []interface{}{11}},
+		parsedVersion{"11.0", []interface{}{11}},
+		parsedVersion{"1-1", []interface{}{1, []interface{}{1}}},
+		parsedVersion{"1-1-1", []interface{}{1, []interface{}{1, []interface{}{1}}}},
+		parsedVersion{" 1 ", []interface{}{1}},
 		// test qualifeirs
-		Version{"1.0-ALPHA", []interface{}{1, []interface{}{"alpha"}}},
-		Version{"1-alpha", []interface{}{1, []interface{}{"alpha"}}},
-		Version{"1.0ALPHA", []interface{}{1, []interface{}{"alpha"}}},
-		Version{"1-alpha", []interface{}{1, []interface{}{"alpha"}}},
-		Version{"1.0-A", []interface{}{1, []interface{}{"a"}}},
-		Version{"1-a", []interface{}{1, []interface{}{"a"}}},
-		Version{"1.0A", []interface{}{1, []interface{}{"a"}}},
-		Version{"1a", []interface{}{1, []interface{}{"a"}}},
-		Version{"1.0-BETA", []interface{}{1, []interface{}{"beta"}}},
-		Version{"1-beta", []interface{}{1, []interface{}{"beta"}}},
-		Version{"1.0-B", []interface{}{1, []interface{}{"b"}}},
-		Version{"1-b", []interface{}{1, []interface{}{"b"}}},
-		Version{"1.0B", []interface{}{1, []interface{}{"b"}}},
-		Version{"1b", []interface{}{1, []interface{}{"b"}}},
-		Version{"1.0-MILESTONE", []interface{}{1, []interface{}{"milestone"}}},
-		Version{"1.0-milestone", []interface{}{1, []interface{}{"milestone"}}},
-		Version{"1-M", []interface{}{1, []interface{}{"m"}}},
-		Version{"1.0-m", []interface{}{1, []interface{}{"m"}}},
-		Version{"1M", []interface{}{1, []interface{}{"m"}}},
-		Version{"1m", []interface{}{1, []interface{}{"m"}}},
-		Version{"1.0-RC", []interface{}{1, []interface{}{"rc"}}},
-		Version{"1-rc", []interface{}{1, []interface{}{"rc"}}},
-		Version{"1.0-SNAPSHOT", []interface{}{1, []interface{}{"snapshot"}}},
-		Version{"1.0-snapshot", []interface{}{1, []interface{}{"snapshot"}}},
-		Version{"1-SP", []interface{}{1, []interface{}{"sp"}}},
-		Version{"1.0-sp", []interface{}{1, []interface{}{"sp"}}},
-		Version{"1-GA", []interface{}{1}},
-		Version{"1-ga", []interface{}{1}},
-		Version{"1.0-FINAL", []interface{}{1}},
-		Version{"1-final", []interface{}{1}},
-		Version{"1.0-CR", []interface{}{1, []interface{}{"rc"}}},
-		Version{"1-cr", []interface{}{1, []interface{}{"rc"}}},
+		parsedVersion{"1.0-ALPHA", []interface{}{1, []interface{}{"alpha"}}},
+		parsedVersion{"1-alpha", []interface{}{1, []interface{}{"alpha"}}},
+		parsedVersion{"1.0ALPHA", []interface{}{1, []interface{}{"alpha"}}},
+		parsedVersion{"1-alpha", []interface{}{1, []interface{}{"alpha"}}},
+		parsedVersion{"1.0-A", []interface{}{1, []interface{}{"a"}}},
+		parsedVersion{"1-a", []interface{}{1, []interface{}{"a"}}},
+		parsedVersion{"1.0A", []interface{}{1, []interface{}{"a"}}},
+		parsedVersion{"1a", []interface{}{1, []interface{}{"a"}}},
+		parsedVersion{"1.0-BETA", []interface{}{1, []interface{}{"beta"}}},
+		parsedVersion{"1-beta", []interface{}{1, []interface{}{"beta"}}},
+		parsedVersion{"1.0-B", []interface{}{1, []interface{}{"b"}}},
+		parsedVersion{"1-b", []interface{}{1, []interface{}{"b"}}},
+		parsedVersion{"1.0B", []interface{}{1, []interface{}{"b"}}},
+		parsedVersion{"1b", []interface{}{1, []interface{}{"b"}}},
+		parsedVersion{"1.0-MILESTONE", []interface{}{1, []interface{}{"milestone"}}},
+		parsedVersion{"1.0-milestone", []interface{}{1, []interface{}{"milestone"}}},
+		parsedVersion{"1-M", []interface{}{1, []interface{}{"m"}}},
+		parsedVersion{"1.0-m", []interface{}{1, []interface{}{"m"}}},
+		parsedVersion{"1M", []interface{}{1, []interface{}{"m"}}},
+		parsedVersion{"1m", []interface{}{1, []interface{}{"m"}}},
+		parsedVersion{"1.0-RC", []interface{}{1, []interface{}{"rc"}}},
+		parsedVersion{"1-rc", []interface{}{1, []interface{}{"rc"}}},
+		parsedVersion{"1.0-SNAPSHOT", []interface{}{1, []interface{}{"snapshot"}}},
+		parsedVersion{"1.0-snapshot", []interface{}{1, []interface{}{"snapshot"}}},
+		parsedVersion{"1-SP", []interface{}{1, []interface{}{"sp"}}},
+		parsedVersion{"1.0-sp", []interface{}{1, []interface{}{"sp"}}},
+		parsedVersion{"1-GA", []interface{}{1}},
+		parsedVersion{"1-ga", []interface{}{1}},
+		parsedVersion{"1.0-FINAL", []interface{}{1}},
+		parsedVersion{"1-final", []interface{}{1}},
+		parsedVersion{"1.0-CR", []interface{}{1, []interface{}{"rc"}}},
+		parsedVersion{"1-cr", []interface{}{1, []interface{}{"rc"}}},
 		// test some transistion
-		Version{"1.0-alpha1", []interface{}{1, []interface{}{"alpha", []interface{}{1}}}},
-		Version{"1.0-alpha2", []interface{}{1, []interface{}{"alpha", []interface{}{2}}}},
-		Version{"1.0.0alpha1", []interface{}{1, []interface{}{"alpha", []interface{}{1}}}},
-		Version{"1.0-beta1", []interface{}{1, []interface{}{"beta", []interface{}{1}}}},
-		Version{"1-beta2", []interface{}{1, []interface{}{"beta", []interface{}{2}}}},
-		Version{"1.0.0beta1", []interface{}{1, []interface{}{"beta", []interface{}{1}}}},
-		Version{"1.0-BETA1", []interface{}{1, []interface{}{"beta", []interface{}{1}}}},
-		Version{"1-BETA2", []interface{}{1, []interface{}{"beta", []interface{}{2}}}},
-		Version{"1.0.0BETA1", []interface{}{1, []interface{}{"beta", []interface{}{1}}}},
-		Version{"1.0-milestone1", []interface{}{1, []interface{}{"milestone", []interface{}{1}}}},
-		Version{"1.0-milestone2", []interface{}{1, []interface{}{"milestone", []interface{}{2}}}},
-		Version{"1.0.0milestone1", []interface{}{1, []interface{}{"milestone", []interface{}{1}}}},
-		Version{"1.0-MILESTONE1", []interface{}{1, []interface{}{"milestone", []interface{}{1}}}},
-		Version{"1.0-milestone2", []interface{}{1, []interface{}{"milestone", []interface{}{2}}}},
-		Version{"1.0.0MILESTONE1", []interface{}{1, []interface{}{"milestone", []interface{}{1}}}},
-		Version{"1.0-alpha2snapshot", []interface{}{1, []interface{}{"alpha", []interface{}{2, []interface{}{"snapshot"}}}}},
+		parsedVersion{"1.0-alpha1", []interface{}{1, []interface{}{"alpha", []interface{}{1}}}},
+		parsedVersion{"1.0-alpha2", []interface{}{1, []interface{}{"alpha", []interface{}{2}}}},
+		parsedVersion{"1.0.0alpha1", []interface{}{1, []interface{}{"alpha", []interface{}{1}}}},
+		parsedVersion{"1.0-beta1", []interface{}{1, []interface{}{"beta", []interface{}{1}}}},
+		parsedVersion{"1-beta2", []interface{}{1, []interface{}{"beta", []interface{}{2}}}},
+		parsedVersion{"1.0.0beta1", []interface{}{1, []interface{}{"beta", []interface{}{1}}}},
+		parsedVersion{"1.0-BETA1", []interface{}{1, []interface{}{"beta", []interface{}{1}}}},
+		parsedVersion{"1-BETA2", []interface{}{1, []interface{}{"beta", []interface{}{2}}}},
+		parsedVersion{"1.0.0BETA1", []interface{}{1, []interface{}{"beta", []interface{}{1}}}},
+		parsedVersion{"1.0-milestone1", []interface{}{1, []interface{}{"milestone", []interface{}{1}}}},
+		parsedVersion{"1.0-milestone2", []interface{}{1, []interface{}{"milestone", []interface{}{2}}}},
+		parsedVersion{"1.0.0milestone1", []interface{}{1, []interface{}{"milestone", []interface{}{1}}}},
+		parsedVersion{"1.0-MILESTONE1", []interface{}{1, []interface{}{"milestone", []interface{}{1}}}},
+		parsedVersion{"1.0-milestone2", []interface{}{1, []interface{}{"milestone", []interface{}{2}}}},
+		parsedVersion{"1.0.0MILESTONE1", []interface{}{1, []interface{}{"milestone", []interface{}{1}}}},
+		parsedVersion{"1.0-alpha2snapshot", []interface{}{1, []interface{}{"alpha", []interface{}{2, []interface{}{"snapshot"}}}}},
 	}
 
 	t.Parallel()
 	for _, want := range tests {
 		t.Run(want.unparsed, func(t *testing.T) {
 			got := New(want.unparsed)
-			if !reflect.DeepEqual(got, &want) {
-				t.Errorf("New(%v): got %v, want %v", want.unparsed, got, want)
+			if got.String() != want.unparsed || !reflect.DeepEqual(got.tree(), want.parsed) {
+				t.Errorf("New(%v): got %v, want %v", want.unparsed, got.tree(), want.parsed)
 			}
 		})
 	}
@@ -387,6 +338,153 @@ func TestVersionCompare(t *testing.T) {
 	}
 }
 
+func TestVersionMethods(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		want  int
+		equal bool
+		less  bool
+	}{
+		{"1.0", "1", 0, true, false},
+		{"1.0-alpha-1", "1.0", -1, false, true},
+		{"1.0-sp", "1.0", 1, false, false},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, b := New(tt.a), New(tt.b)
+			if got := sign(a.Compare(b)); got != tt.want {
+				t.Errorf("Compare: got %d, want %d", got, tt.want)
+			}
+			if got := a.Equal(b); got != tt.equal {
+				t.Errorf("Equal: got %v, want %v", got, tt.equal)
+			}
+			if got := a.LessThan(b); got != tt.less {
+				t.Errorf("LessThan: got %v, want %v", got, tt.less)
+			}
+		})
+	}
+}
+
+// TestVercmpNumberNewerThanQualifier covers a change in behavior: a number,
+// even 0, is newer than a qualifier or list in the same position, as it is in
+// Maven. Vercmp used to find 0 equal to any qualifier or list, so 1.x.1 and
+// 1.0.1 compared as equal one way round and not the other.
+func TestVercmpNumberNewerThanQualifier(t *testing.T) {
+	tests := []struct{ low, high string }{
+		{"1.x.1", "1.0.1"},
+		{"1.x", "1.0.alpha"},
+		{"1.alpha.1", "1.0.1"},
+		{"1-1.1", "1.0.1"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.low+" < "+tt.high, func(t *testing.T) {
+			if !assertVersionOrder(tt.low, tt.high) {
+				t.Error("got false")
+			}
+		})
+	}
+}
+
+func TestVercmpTypes(t *testing.T) {
+	want := -1
+	for _, a := range []interface{}{"1.0", New("1.0"), *New("1.0")} {
+		for _, b := range []interface{}{"1.1", New("1.1"), *New("1.1")} {
+			if got := sign(Vercmp(a, b)); got != want {
+				t.Errorf("Vercmp(%#v, %#v): got %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestVercmpUnsupportedType(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("got no panic, want panic")
+		}
+	}()
+	Vercmp(1, "1.0")
+}
+
+func TestVersionText(t *testing.T) {
+	tests := []string{"1.0", "1.0-SNAPSHOT", " 1-Alpha2 ", ""}
+
+	t.Parallel()
+	for _, v := range tests {
+		t.Run(v, func(t *testing.T) {
+			text, err := New(v).MarshalText()
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if string(text) != v {
+				t.Errorf("got %q, want %q", text, v)
+			}
+			var got Version
+			if err := got.UnmarshalText(text); err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if !reflect.DeepEqual(&got, New(v)) {
+				t.Errorf("got %v, want %v", got, New(v))
+			}
+		})
+	}
+}
+
+func TestVersionJSON(t *testing.T) {
+	type config struct {
+		Version  Version
+		Pointer  *Version
+		Versions []Version
+		Dates    map[Version]string
+	}
+	data := `{"Version":"1.0-rc1","Pointer":"2.0","Versions":["1","1.1-SNAPSHOT"],"Dates":{"1.0":"2019-01-01","1.1-SNAPSHOT":"2019-01-05"}}`
+
+	var got config
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	want := config{
+		*New("1.0-rc1"),
+		New("2.0"),
+		[]Version{*New("1"), *New("1.1-SNAPSHOT")},
+		map[Version]string{
+			*New("1.0"):          "2019-01-01",
+			*New("1.1-SNAPSHOT"): "2019-01-05",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if date := got.Dates[*New("1.0")]; date != "2019-01-01" {
+		t.Errorf("Dates[1.0]: got %q, want 2019-01-01", date)
+	}
+	// Map keys go by the original string, so an equal version parsed from
+	// another string is a different key.
+	if date, ok := got.Dates[*New("1")]; ok {
+		t.Errorf("Dates[1]: got %q, want no entry", date)
+	}
+
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if string(b) != data {
+		t.Errorf("got %s, want %s", b, data)
+	}
+}
+
+func TestVersionSortKeyMapKey(t *testing.T) {
+	dates := map[string]string{string(New("1.0").SortKey()): "2019-01-01"}
+	for _, v := range []string{"1", "1.0", "1-ga", "1.0.0-final"} {
+		if date := dates[string(New(v).SortKey())]; date != "2019-01-01" {
+			t.Errorf("%s: got %q, want 2019-01-01", v, date)
+		}
+	}
+}
+
 func BenchmarkVercmp(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		Vercmp("1.2.3-milestone.1", "1.2.3-milestone.2")
@@ -421,3 +519,33 @@ func assertVersionOrder(low, high string) bool {
 	}
 	return true
 }
+
+func TestParseCache(t *testing.T) {
+	c := ParseCache()
+	if got := c.Stats().Capacity; got != 0 {
+		t.Errorf("default capacity: got %d, want a disabled cache", got)
+	}
+	c.Resize(100)
+	defer c.Resize(0)
+	c.Purge()
+	defer c.Purge()
+
+	Vercmp("1.0", "1.1")
+	Vercmp("1.0", "1.1")
+	if got := c.Stats(); got.Hits != 2 || got.Misses != 2 || got.Len != 2 {
+		t.Errorf("after comparing the same strings twice: got %+v, want 2 hits, 2 misses and 2 versions", got)
+	}
+
+	Maven30.Comparator().Vercmp("1.0", "1.2")
+	if got := c.Stats(); got.Hits != 2 || got.Misses != 2 {
+		t.Errorf("after comparing with another comparator: got %+v, want the cache unused", got)
+	}
+
+	c.Resize(0)
+	if got := Vercmp("1.0", "1.1"); got >= 0 {
+		t.Errorf("Vercmp(1.0, 1.1) without a cache: got %d, want < 0", got)
+	}
+	if got := c.Stats(); got.Len != 0 || got.Misses != 2 {
+		t.Errorf("after disabling the cache: got %+v, want no versions and no new misses", got)
+	}
+}
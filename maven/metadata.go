@@ -0,0 +1,102 @@
+package maven
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Metadata is the artifact level maven-metadata.xml of a repository, which
+// lists the versions deployed for an artifact.
+type Metadata struct {
+	GroupID    string     `xml:"groupId"`
+	ArtifactID string     `xml:"artifactId"`
+	Versioning Versioning `xml:"versioning"`
+}
+
+// Versioning is the versioning element of a maven-metadata.xml file. Latest
+// and Release are the values recorded by the repository, which are not
+// always in Maven order.
+type Versioning struct {
+	Latest      string   `xml:"latest"`
+	Release     string   `xml:"release"`
+	Versions    []string `xml:"versions>version"`
+	LastUpdated string   `xml:"lastUpdated"`
+}
+
+// Discrepancy records a versioning field whose value disagrees with the
+// version computed from the list of versions.
+type Discrepancy struct {
+	Field    string
+	Recorded string
+	Computed string
+}
+
+func (d Discrepancy) String() string {
+	return fmt.Sprintf("<%s> is %q, want %q", d.Field, d.Recorded, d.Computed)
+}
+
+// ParseMetadata reads a maven-metadata.xml file from r.
+func ParseMetadata(r io.Reader) (*Metadata, error) {
+	m := new(Metadata)
+	if err := xml.NewDecoder(r).Decode(m); err != nil {
+		return nil, fmt.Errorf("invalid maven metadata: %v", err)
+	}
+	versions := m.Versioning.Versions[:0]
+	for _, v := range m.Versioning.Versions {
+		if v = strings.TrimSpace(v); v != "" {
+			versions = append(versions, v)
+		}
+	}
+	m.Versioning.Versions = versions
+	m.Versioning.Latest = strings.TrimSpace(m.Versioning.Latest)
+	m.Versioning.Release = strings.TrimSpace(m.Versioning.Release)
+	return m, nil
+}
+
+// Latest returns the newest version of m, including snapshots, or "" if m
+// lists no versions.
+func (m *Metadata) Latest() string {
+	return Max(m.Versioning.Versions)
+}
+
+// Release returns the newest version of m that is not a snapshot, or "" if
+// there is none.
+func (m *Metadata) Release() string {
+	var releases []string
+	for _, v := range m.Versioning.Versions {
+		if !IsSnapshot(v) {
+			releases = append(releases, v)
+		}
+	}
+	return Max(releases)
+}
+
+// Highest returns the newest version of m that r contains, or "" if there is
+// none. A soft requirement contains every version, so its highest version is
+// the latest.
+func (m *Metadata) Highest(r *Range) string {
+	var matches []string
+	for _, v := range m.Versioning.Versions {
+		if r.Contains(New(v)) {
+			matches = append(matches, v)
+		}
+	}
+	return Max(matches)
+}
+
+// Discrepancies returns the recorded latest and release versions of m that
+// are not the newest versions in Maven order. Fields that the file leaves
+// empty are not reported.
+func (m *Metadata) Discrepancies() []Discrepancy {
+	var ds []Discrepancy
+	check := func(field, recorded, computed string) {
+		if recorded != "" && Vercmp(recorded, computed) != 0 {
+			ds = append(ds, Discrepancy{field, recorded, computed})
+		}
+	}
+	check("latest", m.Versioning.Latest, m.Latest())
+	check("release", m.Versioning.Release, m.Release())
+	return ds
+}
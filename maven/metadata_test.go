@@ -0,0 +1,99 @@
+package maven
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func loadMetadata(t *testing.T) *Metadata {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", "maven-metadata.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	m, err := ParseMetadata(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestParseMetadata(t *testing.T) {
+	m := loadMetadata(t)
+	if m.GroupID != "org.example" || m.ArtifactID != "example-core" {
+		t.Errorf("got %s:%s, want org.example:example-core", m.GroupID, m.ArtifactID)
+	}
+	want := Versioning{
+		Latest:  "1.10.0-SNAPSHOT",
+		Release: "1.9.0",
+		Versions: []string{
+			"1.0", "1.1.0", "1.2.0-beta-1", "1.2.0", "1.9.0", "1.10.0-rc1", "1.10.0-SNAPSHOT", "1.2.1",
+		},
+		LastUpdated: "20230514103022",
+	}
+	if !reflect.DeepEqual(m.Versioning, want) {
+		t.Errorf("got %+v, want %+v", m.Versioning, want)
+	}
+
+	if _, err := ParseMetadata(strings.NewReader("<metadata>")); err == nil {
+		t.Error("truncated xml: got nil, want error")
+	}
+}
+
+func TestMetadataSelection(t *testing.T) {
+	m := loadMetadata(t)
+	if got, want := m.Latest(), "1.10.0-SNAPSHOT"; got != want {
+		t.Errorf("Latest: got %q, want %q", got, want)
+	}
+	if got, want := m.Release(), "1.10.0-rc1"; got != want {
+		t.Errorf("Release: got %q, want %q", got, want)
+	}
+
+	tests := []struct {
+		spec, want string
+	}{
+		{"[1.0,2.0)", "1.10.0-SNAPSHOT"},
+		{"[1.0,1.10.0-rc1)", "1.9.0"},
+		{"[1.2,1.2.99]", "1.2.1"},
+		{"(,1.2.0)", "1.2.0-beta-1"},
+		{"[1.0]", "1.0"},
+		{"[2.0,)", ""},
+		{"1.1.0", "1.10.0-SNAPSHOT"},
+	}
+	for _, tt := range tests {
+		r, err := ParseRange(tt.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := m.Highest(r); got != tt.want {
+			t.Errorf("Highest(%s): got %q, want %q", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestMetadataDiscrepancies(t *testing.T) {
+	m := loadMetadata(t)
+	want := []Discrepancy{{"release", "1.9.0", "1.10.0-rc1"}}
+	if got := m.Discrepancies(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	m.Versioning.Release = "1.10.0-RC1"
+	m.Versioning.Latest = ""
+	if got := m.Discrepancies(); got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+
+	m.Versioning.Latest = "1.2.1"
+	want = []Discrepancy{{"latest", "1.2.1", "1.10.0-SNAPSHOT"}}
+	if got := m.Discrepancies(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := want[0].String(), `<latest> is "1.2.1", want "1.10.0-SNAPSHOT"`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
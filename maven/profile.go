@@ -0,0 +1,88 @@
+package maven
+
+import "strconv"
+
+// Profile selects the ComparableVersion semantics of a Maven release, so that
+// versions compare the way the Maven that runs a build compares them.
+type Profile int
+
+const (
+	// Maven30 follows Maven 3.0. A "-" only starts a nested list when it
+	// separates two numbers, so 1-1 and 1.1 differ, while transitions between
+	// letters and digits never nest: 1.0-alpha1 parses as 1.alpha.1. Trailing
+	// null items are only removed up to the last one that is not null.
+	Maven30 Profile = iota + 1
+	// Maven36 follows Maven 3.1 to 3.8, where every "-" and every
+	// transition between letters and digits starts a nested list. It is the
+	// profile of New, Vercmp and NewComparator.
+	Maven36
+	// Maven39 follows Maven 3.9. It extends Maven36 by comparing a whole
+	// list with a missing item rather than just its first item (MNG-6964),
+	// by aliasing release to a release version, and by starting a nested
+	// list at a qualifier that follows a "." (MNG-7644), so 2.0.x equals
+	// 2-x and 1.0.0.x1 is older than 1.0.0-x2.
+	Maven39
+)
+
+// DefaultProfile is the profile of the package-level functions.
+const DefaultProfile = Maven36
+
+var profileNames = map[Profile]string{
+	Maven30: "Maven 3.0",
+	Maven36: "Maven 3.6",
+	Maven39: "Maven 3.9",
+}
+
+func (p Profile) String() string {
+	if name, ok := profileNames[p]; ok {
+		return name
+	}
+	return "Profile(" + strconv.Itoa(int(p)) + ")"
+}
+
+// profileComparators are shared, so that versions parsed by the Comparators
+// of the same profile are ==.
+var profileComparators = map[Profile]*Comparator{
+	Maven30: newProfileComparator(Maven30),
+	Maven36: defaultComparator,
+	Maven39: newProfileComparator(Maven39),
+}
+
+// Comparator returns a Comparator with the default qualifiers and aliases of
+// the Maven release p follows. It panics if p is not a known profile.
+func (p Profile) Comparator() *Comparator {
+	c, ok := profileComparators[p]
+	if !ok {
+		panic("maven: unknown profile " + p.String())
+	}
+	return c
+}
+
+func newProfileComparator(p Profile) *Comparator {
+	aliases := DefaultAliases()
+	if p == Maven39 {
+		aliases["release"] = ""
+	}
+	c, err := NewComparator(DefaultQualifiers(), aliases)
+	if err != nil {
+		panic(err)
+	}
+	c.profile = p
+	return c
+}
+
+// Profile returns the profile of c.
+func (c *Comparator) Profile() Profile {
+	return c.profile
+}
+
+// WithProfile returns a copy of c that follows the semantics of profile p,
+// keeping c's qualifiers and aliases. It panics if p is not a known profile.
+func (c *Comparator) WithProfile(p Profile) *Comparator {
+	if _, ok := profileNames[p]; !ok {
+		panic("maven: unknown profile " + p.String())
+	}
+	copied := *c
+	copied.profile = p
+	return &copied
+}
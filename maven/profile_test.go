@@ -0,0 +1,202 @@
+package maven
+
+import (
+	"reflect"
+	"testing"
+)
+
+var profiles = []Profile{Maven30, Maven36, Maven39}
+
+func TestProfileOrdering(t *testing.T) {
+	// Maven's own ordering tests hold for every profile, in the form they
+	// had in the Maven release the profile follows.
+	seqs := loadCorpus(t)
+	for _, p := range profiles {
+		c := p.Comparator()
+		for _, name := range []string{"qualifiers.txt", "numbers.txt"} {
+			found := false
+			for _, seq := range seqs {
+				if seq.file != name || !hasProfile(seq.profiles, p) {
+					continue
+				}
+				found = true
+				list := seq.lines
+				for i, low := range list {
+					for j, high := range list {
+						for _, a := range low {
+							for _, b := range high {
+								if got, want := sign(c.Vercmp(a, b)), sign(i-j); got != want {
+									t.Errorf("%s: Vercmp(%s, %s): got %d, want %d", p, a, b, got, want)
+								}
+							}
+						}
+					}
+				}
+			}
+			if !found {
+				t.Errorf("%s: no sequence in %s", p, name)
+			}
+		}
+	}
+}
+
+func TestProfileParse(t *testing.T) {
+	tests := []struct {
+		v    string
+		want map[Profile][]interface{}
+	}{
+		{"1-1", map[Profile][]interface{}{
+			Maven30: {1, []interface{}{1}},
+			Maven36: {1, []interface{}{1}},
+			Maven39: {1, []interface{}{1}},
+		}},
+		{"1.0-alpha1", map[Profile][]interface{}{
+			Maven30: {1, "alpha", 1},
+			Maven36: {1, []interface{}{"alpha", []interface{}{1}}},
+			Maven39: {1, []interface{}{"alpha", []interface{}{1}}},
+		}},
+		{"1.0.0-x1", map[Profile][]interface{}{
+			Maven30: {1, "x", 1},
+			Maven36: {1, []interface{}{"x", []interface{}{1}}},
+			Maven39: {1, []interface{}{"x", []interface{}{1}}},
+		}},
+		{"1.0.0.x1", map[Profile][]interface{}{
+			Maven30: {1, 0, 0, "x", 1},
+			Maven36: {1, 0, 0, "x", []interface{}{1}},
+			Maven39: {1, []interface{}{"x", []interface{}{1}}},
+		}},
+		{"2.0.x", map[Profile][]interface{}{
+			Maven30: {2, 0, "x"},
+			Maven36: {2, 0, "x"},
+			Maven39: {2, []interface{}{"x"}},
+		}},
+		{"1.0alpha", map[Profile][]interface{}{
+			Maven30: {1, 0, "alpha"},
+			Maven36: {1, []interface{}{"alpha"}},
+			Maven39: {1, []interface{}{"alpha"}},
+		}},
+		{"1-ga-1", map[Profile][]interface{}{
+			Maven30: {1, "", 1},
+			Maven36: {1, []interface{}{[]interface{}{1}}},
+			Maven39: {1, []interface{}{[]interface{}{1}}},
+		}},
+		{"1-0-1", map[Profile][]interface{}{
+			Maven30: {1, []interface{}{[]interface{}{1}}},
+			Maven36: {1, []interface{}{[]interface{}{1}}},
+			Maven39: {1, []interface{}{[]interface{}{1}}},
+		}},
+		{"1.0-release", map[Profile][]interface{}{
+			Maven30: {1, "release"},
+			Maven36: {1, []interface{}{"release"}},
+			Maven39: {1},
+		}},
+	}
+
+	for _, tt := range tests {
+		for _, p := range profiles {
+			if got := p.Comparator().Parse(tt.v).tree(); !reflect.DeepEqual(got, tt.want[p]) {
+				t.Errorf("%s: Parse(%s): got %v, want %v", p, tt.v, got, tt.want[p])
+			}
+		}
+	}
+}
+
+func TestProfileCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want map[Profile]int
+	}{
+		{"1-1", "1.1", map[Profile]int{Maven30: -1, Maven36: -1, Maven39: -1}},
+		{"1.0-alpha1", "1.0-alpha-1", map[Profile]int{Maven30: 0, Maven36: 0, Maven39: 0}},
+		// Only Maven 3.9 looks past the leading zero of a nested list.
+		{"1-0.1", "1", map[Profile]int{Maven30: 0, Maven36: 0, Maven39: 1}},
+		{"1-0-alpha", "1", map[Profile]int{Maven30: -1, Maven36: -1, Maven39: -1}},
+		// Only Maven 3.9 knows the release alias.
+		{"1.0-release", "1", map[Profile]int{Maven30: 1, Maven36: 1, Maven39: 0}},
+		// Before Maven 3.9 a qualifier after a "." stays in the same list as
+		// the numbers; Maven 3.9 nests it like one after a "-" (MNG-7644).
+		{"1.0.0.x1", "1.0.0-x2", map[Profile]int{Maven30: 1, Maven36: 1, Maven39: -1}},
+		{"2.0.x", "2-x", map[Profile]int{Maven30: 1, Maven36: 1, Maven39: 0}},
+		{"2.0.0.x", "2.0.x", map[Profile]int{Maven30: 1, Maven36: 1, Maven39: 0}},
+		{"1-ga-1", "1-1", map[Profile]int{Maven30: -1, Maven36: -1, Maven39: -1}},
+		{"1.0alpha", "1.0.0", map[Profile]int{Maven30: -1, Maven36: -1, Maven39: -1}},
+		{"1alpha.1", "1-alpha.1", map[Profile]int{Maven30: 0, Maven36: 0, Maven39: 0}},
+	}
+
+	for _, tt := range tests {
+		for _, p := range profiles {
+			c := p.Comparator()
+			if got := sign(c.Vercmp(tt.a, tt.b)); got != tt.want[p] {
+				t.Errorf("%s: Vercmp(%s, %s): got %d, want %d", p, tt.a, tt.b, got, tt.want[p])
+			}
+			if got := sign(c.Vercmp(tt.b, tt.a)); got != -tt.want[p] {
+				t.Errorf("%s: Vercmp(%s, %s): got %d, want %d", p, tt.b, tt.a, got, -tt.want[p])
+			}
+		}
+	}
+}
+
+func TestDefaultProfile(t *testing.T) {
+	if got := defaultComparator.Profile(); got != Maven36 {
+		t.Errorf("got %s, want %s", got, Maven36)
+	}
+	c := Maven36.Comparator()
+	all := append([]string{"1-0.1", "1.0-release", "1.0.0.x1"}, mavenVersions(t)...)
+	for _, a := range all {
+		for _, b := range all {
+			if got, want := sign(c.Vercmp(a, b)), sign(Vercmp(a, b)); got != want {
+				t.Errorf("Vercmp(%s, %s): got %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestWithProfile(t *testing.T) {
+	custom, err := NewComparator([]string{"preview", ""}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := custom.WithProfile(Maven30)
+	if got := c.Profile(); got != Maven30 {
+		t.Errorf("got %s, want %s", got, Maven30)
+	}
+	if got := custom.Profile(); got != DefaultProfile {
+		t.Errorf("original: got %s, want %s", got, DefaultProfile)
+	}
+	if got := sign(c.Vercmp("1-preview", "1")); got != -1 {
+		t.Errorf("got %d, want -1", got)
+	}
+	if got := c.Parse("1.0-preview1").tree(); !reflect.DeepEqual(got, []interface{}{1, "preview", 1}) {
+		t.Errorf("got %v, want [1 preview 1]", got)
+	}
+}
+
+func TestProfileString(t *testing.T) {
+	tests := map[Profile]string{
+		Maven30:    "Maven 3.0",
+		Maven36:    "Maven 3.6",
+		Maven39:    "Maven 3.9",
+		Profile(0): "Profile(0)",
+	}
+	for p, want := range tests {
+		if got := p.String(); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestUnknownProfile(t *testing.T) {
+	for _, f := range []func(){
+		func() { Profile(0).Comparator() },
+		func() { defaultComparator.WithProfile(Profile(42)) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("got no panic, want panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,175 @@
+package maven
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Restriction is a single interval of versions within a Range. A nil bound
+// leaves that side of the interval unbounded.
+type Restriction struct {
+	Lower, Upper                   *Version
+	LowerInclusive, UpperInclusive bool
+}
+
+// Contains reports whether v lies within r.
+func (r Restriction) Contains(v *Version) bool {
+	if r.Lower != nil {
+		c := v.Compare(r.Lower)
+		if c < 0 || c == 0 && !r.LowerInclusive {
+			return false
+		}
+	}
+	if r.Upper != nil {
+		c := v.Compare(r.Upper)
+		if c > 0 || c == 0 && !r.UpperInclusive {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Restriction) String() string {
+	if r.Lower != nil && r.Upper != nil && r.LowerInclusive && r.UpperInclusive && r.Lower.Equal(r.Upper) {
+		return "[" + r.Lower.String() + "]"
+	}
+	var b strings.Builder
+	if r.LowerInclusive {
+		b.WriteByte('[')
+	} else {
+		b.WriteByte('(')
+	}
+	if r.Lower != nil {
+		b.WriteString(r.Lower.String())
+	}
+	b.WriteByte(',')
+	if r.Upper != nil {
+		b.WriteString(r.Upper.String())
+	}
+	if r.UpperInclusive {
+		b.WriteByte(']')
+	} else {
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+// Range is a Maven version range, such as "[1.0,2.0)" or "(,1.0],[1.2,)".
+//
+// A plain version, such as "1.0", is a soft requirement: it recommends a
+// version but allows any. Its Range has that Recommended version and a single
+// unbounded Restriction.
+type Range struct {
+	Recommended  *Version
+	Restrictions []Restriction
+}
+
+// ParseRange parses a Maven version range specification.
+func ParseRange(spec string) (*Range, error) {
+	s := strings.TrimSpace(spec)
+	if s == "" {
+		return nil, fmt.Errorf("invalid version range %q: empty range", spec)
+	}
+	if s[0] != '[' && s[0] != '(' {
+		return &Range{Recommended: New(s), Restrictions: []Restriction{{}}}, nil
+	}
+
+	r := &Range{}
+	for s != "" {
+		end := strings.IndexAny(s, "])")
+		if end < 0 {
+			return nil, fmt.Errorf("invalid version range %q: unbounded range", spec)
+		}
+		restriction, err := parseRestriction(s[:end+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid version range %q: %v", spec, err)
+		}
+		if n := len(r.Restrictions); n > 0 {
+			prev := r.Restrictions[n-1]
+			if prev.Upper == nil || restriction.Lower == nil || restriction.Lower.Compare(prev.Upper) < 0 {
+				return nil, fmt.Errorf("invalid version range %q: ranges overlap", spec)
+			}
+		}
+		r.Restrictions = append(r.Restrictions, restriction)
+
+		s = strings.TrimSpace(s[end+1:])
+		if s != "" {
+			if s[0] != ',' {
+				return nil, fmt.Errorf("invalid version range %q: expected ',' between ranges", spec)
+			}
+			s = strings.TrimSpace(s[1:])
+			if s == "" || s[0] != '[' && s[0] != '(' {
+				return nil, fmt.Errorf("invalid version range %q: expected a range after ','", spec)
+			}
+		}
+	}
+	return r, nil
+}
+
+// parseRestriction parses a single bracketed interval.
+func parseRestriction(s string) (Restriction, error) {
+	r := Restriction{
+		LowerInclusive: s[0] == '[',
+		UpperInclusive: s[len(s)-1] == ']',
+	}
+	inner := strings.TrimSpace(s[1 : len(s)-1])
+	if strings.ContainsAny(inner, "[(") {
+		return r, fmt.Errorf("unexpected bracket in %s", s)
+	}
+	comma := strings.IndexByte(inner, ',')
+	if comma < 0 {
+		if !r.LowerInclusive || !r.UpperInclusive {
+			return r, fmt.Errorf("single version %s must be surrounded by []", s)
+		}
+		if inner == "" {
+			return r, fmt.Errorf("empty range %s", s)
+		}
+		r.Lower = New(inner)
+		r.Upper = r.Lower
+		return r, nil
+	}
+
+	lower := strings.TrimSpace(inner[:comma])
+	upper := strings.TrimSpace(inner[comma+1:])
+	if strings.IndexByte(upper, ',') >= 0 {
+		return r, fmt.Errorf("too many versions in %s", s)
+	}
+	if lower != "" {
+		r.Lower = New(lower)
+	}
+	if upper != "" {
+		r.Upper = New(upper)
+	}
+	if r.Lower != nil && r.Upper != nil {
+		c := r.Upper.Compare(r.Lower)
+		if c < 0 {
+			return r, fmt.Errorf("range %s defies version ordering", s)
+		}
+		if c == 0 && (!r.LowerInclusive || !r.UpperInclusive) {
+			return r, fmt.Errorf("range %s cannot contain any version", s)
+		}
+	}
+	return r, nil
+}
+
+// Contains reports whether v lies within any of r's restrictions.
+func (r *Range) Contains(v *Version) bool {
+	for _, restriction := range r.Restrictions {
+		if restriction.Contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the range specification of r.
+func (r *Range) String() string {
+	if r.Recommended != nil {
+		return r.Recommended.String()
+	}
+	parts := make([]string, len(r.Restrictions))
+	for i, restriction := range r.Restrictions {
+		parts[i] = restriction.String()
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,90 @@
+package maven
+
+import "testing"
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		spec, want string
+		in, out    []string
+	}{
+		{"1.0", "1.0", []string{"0.1", "1.0", "99"}, nil},
+		{"[1.0]", "[1.0]", []string{"1", "1.0.0"}, []string{"1.0.1", "1-rc1"}},
+		{"[1.0,2.0)", "[1.0,2.0)", []string{"1.0", "1.5", "2.0-SNAPSHOT"}, []string{"1.0-rc1", "2.0"}},
+		{"(1.0,2.0]", "(1.0,2.0]", []string{"1.0.1", "2.0"}, []string{"1.0", "2.0.1"}},
+		{"[1.0,)", "[1.0,)", []string{"1.0", "99"}, []string{"0.9"}},
+		{"(,1.0]", "(,1.0]", []string{"0.1", "1.0"}, []string{"1.0.1"}},
+		{"(,1.0],[1.2,)", "(,1.0],[1.2,)", []string{"1.0", "1.2"}, []string{"1.1"}},
+		{" ( , 1.0 ] , [ 1.2 , ) ", "(,1.0],[1.2,)", []string{"1.0", "1.2"}, []string{"1.1"}},
+		{"(,1.0),(1.0,)", "(,1.0),(1.0,)", []string{"0.9", "1.1"}, []string{"1.0"}},
+		{"[1.0,1.0]", "[1.0]", []string{"1.0"}, []string{"1.1"}},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			r, err := ParseRange(tt.spec)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got := r.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			for _, v := range tt.in {
+				if !r.Contains(New(v)) {
+					t.Errorf("Contains(%v): got false, want true", v)
+				}
+			}
+			for _, v := range tt.out {
+				if r.Contains(New(v)) {
+					t.Errorf("Contains(%v): got true, want false", v)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRangeRecommended(t *testing.T) {
+	r, err := ParseRange("1.0")
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if r.Recommended == nil || r.Recommended.String() != "1.0" {
+		t.Errorf("got %v, want 1.0", r.Recommended)
+	}
+	r, err = ParseRange("[1.0,2.0)")
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if r.Recommended != nil {
+		t.Errorf("got %v, want nil", r.Recommended)
+	}
+}
+
+func TestParseRangeErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"[1.0",
+		"[1.0,2.0",
+		"(1.0)",
+		"[1.0)",
+		"[]",
+		"[1.0,2.0,3.0]",
+		"[2.0,1.0]",
+		"(1.0,1.0]",
+		"[1.0,2.0],[1.5,3.0]",
+		"(,2.0],[1.0,)",
+		"[1.0,2.0] [3.0,4.0]",
+		"[1.0,2.0],",
+		"[1.0,2.0],3.0",
+		"[1.0,[2.0]",
+	}
+
+	t.Parallel()
+	for _, spec := range tests {
+		t.Run(spec, func(t *testing.T) {
+			if r, err := ParseRange(spec); err == nil {
+				t.Errorf("got %v, want error", r)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package maven
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SnapshotQualifier is the qualifier of a version that is still in
+// development.
+const SnapshotQualifier = "SNAPSHOT"
+
+// SnapshotTimestampFormat is the time layout of the timestamp that replaces
+// SNAPSHOT when a snapshot is deployed to a remote repository. Timestamps are
+// in UTC.
+const SnapshotTimestampFormat = "20060102.150405"
+
+var snapshotRe = regexp.MustCompile(`^(.*)-(\d{8}\.\d{6})-(\d+)$`)
+
+// Snapshot is a snapshot version as deployed to a remote repository, such as
+// 1.2.0-20230514.103022-7.
+type Snapshot struct {
+	// Base is the version without its SNAPSHOT qualifier, such as 1.2.0.
+	Base        string
+	Timestamp   time.Time
+	BuildNumber int
+}
+
+// ParseSnapshot parses the timestamped snapshot version v.
+func ParseSnapshot(v string) (*Snapshot, error) {
+	m := snapshotRe.FindStringSubmatch(strings.TrimSpace(v))
+	if m == nil {
+		return nil, fmt.Errorf("invalid snapshot version %q", v)
+	}
+	ts, err := time.Parse(SnapshotTimestampFormat, m[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot version %q: bad timestamp %s", v, m[2])
+	}
+	n, err := strconv.Atoi(m[3])
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot version %q: bad build number %s", v, m[3])
+	}
+	return &Snapshot{Base: m[1], Timestamp: ts, BuildNumber: n}, nil
+}
+
+// String returns the timestamped version of s.
+func (s *Snapshot) String() string {
+	return fmt.Sprintf("%s-%s-%d", s.Base, s.Timestamp.UTC().Format(SnapshotTimestampFormat), s.BuildNumber)
+}
+
+// BaseVersion returns the declared version of s, such as 1.2.0-SNAPSHOT.
+func (s *Snapshot) BaseVersion() string {
+	return s.Base + "-" + SnapshotQualifier
+}
+
+// Compare returns a negative integer if s is older than other, 0 if they are
+// the same, and a positive integer if s is newer. Snapshots of different base
+// versions are ordered by their base versions, and snapshots of the same base
+// by timestamp and then build number.
+func (s *Snapshot) Compare(other *Snapshot) int {
+	if c := Vercmp(s.Base, other.Base); c != 0 {
+		return c
+	}
+	if s.Timestamp.Before(other.Timestamp) {
+		return -1
+	}
+	if s.Timestamp.After(other.Timestamp) {
+		return 1
+	}
+	return s.BuildNumber - other.BuildNumber
+}
+
+// IsSnapshot reports whether v is a snapshot version, either declared with a
+// SNAPSHOT qualifier or timestamped by a deployment.
+func IsSnapshot(v string) bool {
+	v = strings.TrimSpace(v)
+	return strings.HasSuffix(strings.ToUpper(v), SnapshotQualifier) || IsTimestampedSnapshot(v)
+}
+
+// IsTimestampedSnapshot reports whether v is a snapshot version timestamped
+// by a deployment.
+func IsTimestampedSnapshot(v string) bool {
+	_, err := ParseSnapshot(v)
+	return err == nil
+}
+
+// BaseVersion returns the declared version of v. Timestamped snapshots map
+// back to their -SNAPSHOT version, and any other version is returned as is.
+func BaseVersion(v string) string {
+	s, err := ParseSnapshot(v)
+	if err != nil {
+		return v
+	}
+	return s.BaseVersion()
+}
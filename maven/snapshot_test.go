@@ -0,0 +1,134 @@
+package maven
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestParseSnapshot(t *testing.T) {
+	tests := []struct {
+		in          string
+		base        string
+		timestamp   time.Time
+		buildNumber int
+	}{
+		{"1.2.0-20230514.103022-7", "1.2.0", time.Date(2023, 5, 14, 10, 30, 22, 0, time.UTC), 7},
+		{"1.2.0-alpha-1-20230514.103022-12", "1.2.0-alpha-1", time.Date(2023, 5, 14, 10, 30, 22, 0, time.UTC), 12},
+		{"2-19991231.235959-1", "2", time.Date(1999, 12, 31, 23, 59, 59, 0, time.UTC), 1},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			s, err := ParseSnapshot(tt.in)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if s.Base != tt.base {
+				t.Errorf("Base: got %q, want %q", s.Base, tt.base)
+			}
+			if !s.Timestamp.Equal(tt.timestamp) {
+				t.Errorf("Timestamp: got %v, want %v", s.Timestamp, tt.timestamp)
+			}
+			if s.BuildNumber != tt.buildNumber {
+				t.Errorf("BuildNumber: got %d, want %d", s.BuildNumber, tt.buildNumber)
+			}
+			if got := s.String(); got != tt.in {
+				t.Errorf("String: got %q, want %q", got, tt.in)
+			}
+		})
+	}
+}
+
+func TestParseSnapshotErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"1.2.0",
+		"1.2.0-SNAPSHOT",
+		"1.2.0-20230514.103022",
+		"1.2.0-20230514-7",
+		"1.2.0-2023051.103022-7",
+		"1.2.0-20231314.103022-7",
+		"1.2.0-20230514.253022-7",
+		"1.2.0-20230514.103022-99999999999999999999",
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			if _, err := ParseSnapshot(tt); err == nil {
+				t.Error("got nil, want error")
+			}
+		})
+	}
+}
+
+func TestIsSnapshot(t *testing.T) {
+	tests := []struct {
+		in          string
+		snapshot    bool
+		timestamped bool
+		base        string
+	}{
+		{"1.2.0", false, false, "1.2.0"},
+		{"1.2.0-SNAPSHOT", true, false, "1.2.0-SNAPSHOT"},
+		{"1.2.0-snapshot", true, false, "1.2.0-snapshot"},
+		{"1.2.0-20230514.103022-7", true, true, "1.2.0-SNAPSHOT"},
+		{"1.2.0-rc1-20230514.103022-7", true, true, "1.2.0-rc1-SNAPSHOT"},
+		{"20230514.103022", false, false, "20230514.103022"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := IsSnapshot(tt.in); got != tt.snapshot {
+				t.Errorf("IsSnapshot: got %v, want %v", got, tt.snapshot)
+			}
+			if got := IsTimestampedSnapshot(tt.in); got != tt.timestamped {
+				t.Errorf("IsTimestampedSnapshot: got %v, want %v", got, tt.timestamped)
+			}
+			if got := BaseVersion(tt.in); got != tt.base {
+				t.Errorf("BaseVersion: got %q, want %q", got, tt.base)
+			}
+		})
+	}
+}
+
+func TestSnapshotCompare(t *testing.T) {
+	// Ordered from oldest to newest.
+	ordered := []string{
+		"1.1.9-20230601.000000-30",
+		"1.2.0-alpha-1-20230514.103022-1",
+		"1.2.0-20230514.103022-7",
+		"1.2.0-20230514.103022-8",
+		"1.2.0-20230514.110000-2",
+		"1.2.0-20230515.090000-9",
+		"1.2.0-20240101.000000-10",
+		"1.10.0-20220101.000000-1",
+	}
+	snapshots := make([]*Snapshot, len(ordered))
+	for i, v := range ordered {
+		s, err := ParseSnapshot(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		snapshots[i] = s
+	}
+
+	for i, a := range snapshots {
+		for j, b := range snapshots {
+			if got, want := sign(a.Compare(b)), sign(i-j); got != want {
+				t.Errorf("%s vs %s: got %d, want %d", a, b, got, want)
+			}
+		}
+	}
+
+	shuffled := []*Snapshot{snapshots[4], snapshots[7], snapshots[0], snapshots[2], snapshots[6], snapshots[1], snapshots[5], snapshots[3]}
+	sort.Slice(shuffled, func(i, j int) bool { return shuffled[i].Compare(shuffled[j]) < 0 })
+	for i, s := range shuffled {
+		if got := s.String(); got != ordered[i] {
+			t.Errorf("sorted[%d]: got %q, want %q", i, got, ordered[i])
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package maven
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Scan implements sql.Scanner. It accepts text columns, which are parsed with
+// the Comparator that parsed m.
+func (m *Version) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		*m = *m.comparator().Parse(src)
+	case []byte:
+		*m = *m.comparator().Parse(string(src))
+	default:
+		return fmt.Errorf("cannot scan %T into a maven version", src)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer. The version is stored as the original
+// version string.
+func (m Version) Value() (driver.Value, error) {
+	return m.unparsed, nil
+}
+
+// Key is a Version that is stored in a database as an order-preserving key
+// rather than as text. Writing Key(*v) to a binary column alongside v lets
+// range queries and ORDER BY compare versions server-side.
+type Key Version
+
+// Value implements driver.Valuer.
+func (k Key) Value() (driver.Value, error) {
+	v := Version(k)
+	return v.SortKey(), nil
+}
+
+// Scan implements sql.Scanner. It accepts binary columns holding a key
+// written by Value, which are decoded with the Comparator that parsed k.
+func (k *Key) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into a maven key", src)
+	}
+	v, err := (*Version)(k).comparator().FromSortKey(b)
+	if err != nil {
+		return err
+	}
+	*k = Key(*v)
+	return nil
+}
@@ -0,0 +1,135 @@
+package maven
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/wfscheper/vercmp/internal/sqltest"
+)
+
+func TestVersionScan(t *testing.T) {
+	tests := []struct {
+		src     interface{}
+		want    *Version
+		wantErr bool
+	}{
+		{"1.0-SNAPSHOT", New("1.0-SNAPSHOT"), false},
+		{[]byte("1.0-rc1"), New("1.0-rc1"), false},
+		{"", New(""), false},
+		{nil, nil, true},
+		{int64(1), nil, true},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("Scan(%v)", tt.src), func(t *testing.T) {
+			var got Version
+			err := got.Scan(tt.src)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("got nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if !reflect.DeepEqual(&got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionValue(t *testing.T) {
+	got, err := New("1.0-RC1").Value()
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if got != "1.0-RC1" {
+		t.Errorf("got %v, want %v", got, "1.0-RC1")
+	}
+}
+
+func TestVersionSQL(t *testing.T) {
+	ordered := []string{"1.0-alpha-1", "1.0-beta-1", "1.0-rc1", "1.0-SNAPSHOT",
+		"1.0", "1.0-sp1", "1.0-xyz", "1.0.1", "1.1", "1.10", "2.0"}
+
+	db, err := sql.Open(sqltest.DriverName, t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("CREATE TABLE versions (version, key)"); err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range []int{5, 2, 9, 0, 7, 10, 3, 1, 8, 4, 6} {
+		v := New(ordered[i])
+		if _, err := db.Exec("INSERT INTO versions VALUES (?, ?)", v, Key(*v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("Order by key", func(t *testing.T) {
+		got := queryVersions(t, db, "SELECT version FROM versions ORDER BY key")
+		if !reflect.DeepEqual(got, ordered) {
+			t.Errorf("got %v, want %v", got, ordered)
+		}
+	})
+
+	t.Run("Range of keys", func(t *testing.T) {
+		got := queryVersions(t, db, "SELECT version FROM versions WHERE key >= ? AND key < ? ORDER BY key",
+			Key(*New("1.0-rc")), Key(*New("1.0.1")))
+		want := []string{"1.0-rc1", "1.0-SNAPSHOT", "1.0", "1.0-sp1", "1.0-xyz"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Scan keys", func(t *testing.T) {
+		rows, err := db.Query("SELECT key FROM versions ORDER BY key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		var got []string
+		for rows.Next() {
+			var k Key
+			if err := rows.Scan(&k); err != nil {
+				t.Fatal(err)
+			}
+			v := Version(k)
+			got = append(got, v.String())
+		}
+		if len(got) != len(ordered) {
+			t.Fatalf("got %d keys, want %d", len(got), len(ordered))
+		}
+		for i := range got {
+			if Vercmp(got[i], ordered[i]) != 0 {
+				t.Errorf("got %v, want %v", got[i], ordered[i])
+			}
+		}
+	})
+}
+
+func queryVersions(t *testing.T, db *sql.DB, query string, args ...interface{}) []string {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var versions []string
+	for rows.Next() {
+		var v Version
+		if err := rows.Scan(&v); err != nil {
+			t.Fatal(err)
+		}
+		versions = append(versions, v.String())
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return versions
+}
@@ -0,0 +1,236 @@
+package maven
+
+import (
+	"math"
+	"math/big"
+	"strings"
+)
+
+// A Version holds its parsed items encoded in a string, which keeps Version
+// comparable so that it can be a map key. Each item starts with a tag:
+//
+//	tagInt n b1..bn   an integer of n big-endian bytes; 0 has no bytes
+//	tagString n s     a qualifier of n bytes
+//	tagList ... tagEnd  a nested list
+//
+// Lengths are unsigned varints. The top-level list has no tags of its own.
+const (
+	tagInt byte = iota + 1
+	tagString
+	tagList
+	tagEnd
+)
+
+// encodeTree returns the encoding of the parsed items in list, which were
+// parsed from a string of n bytes.
+func encodeTree(list []interface{}, n int) string {
+	var b strings.Builder
+	b.Grow(2*n + 4)
+	writeTree(&b, list)
+	return b.String()
+}
+
+func writeTree(b *strings.Builder, list []interface{}) {
+	for _, item := range list {
+		switch item := item.(type) {
+		case int:
+			n := 0
+			for v := item; v > 0; v >>= 8 {
+				n++
+			}
+			b.WriteByte(tagInt)
+			writeLen(b, n)
+			for shift := 8 * (n - 1); shift >= 0; shift -= 8 {
+				b.WriteByte(byte(item >> uint(shift)))
+			}
+		case *big.Int:
+			digits := item.Bytes()
+			b.WriteByte(tagInt)
+			writeLen(b, len(digits))
+			b.Write(digits)
+		case string:
+			b.WriteByte(tagString)
+			writeLen(b, len(item))
+			b.WriteString(item)
+		case []interface{}:
+			b.WriteByte(tagList)
+			writeTree(b, item)
+			b.WriteByte(tagEnd)
+		}
+	}
+}
+
+func writeLen(b *strings.Builder, n int) {
+	for ; n >= 0x80; n >>= 7 {
+		b.WriteByte(byte(n) | 0x80)
+	}
+	b.WriteByte(byte(n))
+}
+
+// tree returns the parsed items of m.
+func (m *Version) tree() []interface{} {
+	r := cursor{s: m.encoded}
+	return r.list()
+}
+
+// cursor reads the items of an encoded version.
+type cursor struct {
+	s string
+	i int
+}
+
+// atEnd reports whether the cursor is at the end of the current list.
+func (r *cursor) atEnd() bool {
+	return r.i >= len(r.s) || r.s[r.i] == tagEnd
+}
+
+// skipEnd moves past the end of the current list.
+func (r *cursor) skipEnd() {
+	if r.i < len(r.s) {
+		r.i++
+	}
+}
+
+// bytes reads the length-prefixed bytes of the item after its tag.
+func (r *cursor) bytes() string {
+	r.i++
+	n := 0
+	for shift := uint(0); ; shift += 7 {
+		c := r.s[r.i]
+		r.i++
+		n |= int(c&0x7f) << shift
+		if c < 0x80 {
+			break
+		}
+	}
+	s := r.s[r.i : r.i+n]
+	r.i += n
+	return s
+}
+
+// skip moves past the next item.
+func (r *cursor) skip() {
+	if r.s[r.i] != tagList {
+		r.bytes()
+		return
+	}
+	r.i++
+	for !r.atEnd() {
+		r.skip()
+	}
+	r.skipEnd()
+}
+
+// list decodes the items up to the end of the current list.
+func (r *cursor) list() []interface{} {
+	list := make([]interface{}, 0)
+	for !r.atEnd() {
+		list = append(list, r.item())
+	}
+	r.skipEnd()
+	return list
+}
+
+// item decodes the next item.
+func (r *cursor) item() interface{} {
+	switch r.s[r.i] {
+	case tagInt:
+		digits := r.bytes()
+		if len(digits) < 8 || len(digits) == 8 && digits[0] < 0x80 {
+			var v uint64
+			for i := 0; i < len(digits); i++ {
+				v = v<<8 | uint64(digits[i])
+			}
+			if v <= math.MaxInt {
+				return int(v)
+			}
+		}
+		return new(big.Int).SetBytes([]byte(digits))
+	case tagString:
+		return r.bytes()
+	}
+	r.i++
+	return r.list()
+}
+
+// typeRanks order items of different kinds: qualifiers are older than lists,
+// which are older than numbers, even 0.
+var typeRanks = [...]int{tagString: 0, tagList: 1, tagInt: 2}
+
+// compareLists compares the items of two encoded lists, without decoding
+// them. A missing item compares like 0 or the release qualifier. When the
+// lists are equal it moves both cursors past their ends.
+func (c *Comparator) compareLists(a, b *cursor) int {
+	for {
+		var result int
+		switch aEnd, bEnd := a.atEnd(), b.atEnd(); {
+		case aEnd && bEnd:
+			a.skipEnd()
+			b.skipEnd()
+			return 0
+		case aEnd:
+			result = -c.compareMissing(b)
+		case bEnd:
+			result = c.compareMissing(a)
+		default:
+			result = c.compareItems(a, b)
+		}
+		if result != 0 {
+			return result
+		}
+	}
+}
+
+// compareItems compares the next items of a and b, and moves past them when
+// they are equal.
+func (c *Comparator) compareItems(a, b *cursor) int {
+	ta, tb := a.s[a.i], b.s[b.i]
+	if ta != tb {
+		return typeRanks[ta] - typeRanks[tb]
+	}
+	switch ta {
+	case tagInt:
+		x, y := a.bytes(), b.bytes()
+		if len(x) != len(y) {
+			return len(x) - len(y)
+		}
+		return strings.Compare(x, y)
+	case tagString:
+		return c.compareQualifiers(a.bytes(), b.bytes())
+	default:
+		a.i++
+		b.i++
+		return c.compareLists(a, b)
+	}
+}
+
+// compareMissing compares the next item of r with a missing item, and moves
+// past it when they are equal.
+func (c *Comparator) compareMissing(r *cursor) int {
+	switch r.s[r.i] {
+	case tagInt:
+		if len(r.bytes()) > 0 {
+			return 1
+		}
+		return 0
+	case tagString:
+		return c.compareQualifiers(r.bytes(), "")
+	}
+	r.i++
+	if c.profile != Maven39 && !r.atEnd() {
+		// Only the first item of a list is compared with a missing item.
+		if result := c.compareMissing(r); result != 0 {
+			return result
+		}
+		for !r.atEnd() {
+			r.skip()
+		}
+	}
+	for !r.atEnd() {
+		if result := c.compareMissing(r); result != 0 {
+			return result
+		}
+	}
+	r.skipEnd()
+	return 0
+}
@@ -0,0 +1,109 @@
+package maven
+
+import "sort"
+
+// Versions is a collection of parsed versions. It implements sort.Interface,
+// ordering versions from oldest to newest.
+type Versions []*Version
+
+// NewVersions parses each of ss once and returns the resulting collection.
+func NewVersions(ss []string) Versions {
+	vs := make(Versions, len(ss))
+	for i, s := range ss {
+		vs[i] = New(s)
+	}
+	return vs
+}
+
+func (vs Versions) Len() int {
+	return len(vs)
+}
+
+func (vs Versions) Less(i, j int) bool {
+	return vs[i].LessThan(vs[j])
+}
+
+func (vs Versions) Swap(i, j int) {
+	vs[i], vs[j] = vs[j], vs[i]
+}
+
+// Strings returns the original version strings of vs.
+func (vs Versions) Strings() []string {
+	ss := make([]string, len(vs))
+	for i, v := range vs {
+		ss[i] = v.unparsed
+	}
+	return ss
+}
+
+// BinarySearch searches the sorted collection vs for v. It returns the index
+// of the first version that is not older than v, and whether that version is
+// equal to v.
+func (vs Versions) BinarySearch(v *Version) (int, bool) {
+	i := sort.Search(len(vs), func(i int) bool {
+		return vs[i].Compare(v) >= 0
+	})
+	return i, i < len(vs) && vs[i].Compare(v) == 0
+}
+
+// Sort returns ss ordered from oldest to newest. Versions that are equal keep
+// their original order.
+func Sort(ss []string) []string {
+	vs := NewVersions(ss)
+	sort.Stable(vs)
+	return vs.Strings()
+}
+
+// Max returns the newest version in ss, or "" if ss is empty. If several
+// versions are equally new, the first is returned.
+func Max(ss []string) string {
+	return extreme(ss, 1)
+}
+
+// Min returns the oldest version in ss, or "" if ss is empty. If several
+// versions are equally old, the first is returned.
+func Min(ss []string) string {
+	return extreme(ss, -1)
+}
+
+func extreme(ss []string, want int) string {
+	if len(ss) == 0 {
+		return ""
+	}
+	vs := NewVersions(ss)
+	best := vs[0]
+	for _, v := range vs[1:] {
+		if v.Compare(best)*want > 0 {
+			best = v
+		}
+	}
+	return best.unparsed
+}
+
+// Dedupe returns ss without the versions that are equal to an earlier
+// version, so "1.0" and "1.0.0" are duplicates of "1". The order of ss is
+// kept.
+func Dedupe(ss []string) []string {
+	vs := NewVersions(ss)
+	idx := make([]int, len(vs))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return vs[idx[i]].LessThan(vs[idx[j]])
+	})
+
+	keep := make([]bool, len(vs))
+	for i, n := range idx {
+		if i == 0 || !vs[idx[i-1]].Equal(vs[n]) {
+			keep[n] = true
+		}
+	}
+	var r []string
+	for i, s := range ss {
+		if keep[i] {
+			r = append(r, s)
+		}
+	}
+	return r
+}
@@ -0,0 +1,84 @@
+package maven
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestVersionsSort(t *testing.T) {
+	vs := NewVersions([]string{"1.1", "1.0-SNAPSHOT", "2", "1.0", "1.0-alpha-1"})
+	sort.Sort(vs)
+	want := []string{"1.0-alpha-1", "1.0-SNAPSHOT", "1.0", "1.1", "2"}
+	if got := vs.Strings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestVersionsBinarySearch(t *testing.T) {
+	vs := NewVersions([]string{"1.0-alpha-1", "1.0-SNAPSHOT", "1.0", "1.1", "2"})
+	tests := []struct {
+		v         string
+		want      int
+		wantFound bool
+	}{
+		{"1.0-alpha", 0, false},
+		{"1.0-alpha-1", 0, true},
+		{"1.0.0", 2, true},
+		{"1.0-sp", 3, false},
+		{"1.1", 3, true},
+		{"2.0.0", 4, true},
+		{"3", 5, false},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.v, func(t *testing.T) {
+			got, found := vs.BinarySearch(New(tt.v))
+			if got != tt.want || found != tt.wantFound {
+				t.Errorf("got %v, %v, want %v, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	got := Sort([]string{"1.1", "1.0.0", "1-rc1", "1.0", "1", "0.9"})
+	want := []string{"0.9", "1-rc1", "1.0.0", "1.0", "1", "1.1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMaxMin(t *testing.T) {
+	tests := []struct {
+		title            string
+		ss               []string
+		wantMax, wantMin string
+	}{
+		{"Empty", nil, "", ""},
+		{"One", []string{"1.0"}, "1.0", "1.0"},
+		{"Qualifiers", []string{"1.0", "1.0-sp", "1.0-rc1", "1.0-SNAPSHOT"}, "1.0-sp", "1.0-rc1"},
+		{"Equal versions", []string{"1.0", "1", "1.0.0"}, "1.0", "1.0"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := Max(tt.ss); got != tt.wantMax {
+				t.Errorf("Max: got %q, want %q", got, tt.wantMax)
+			}
+			if got := Min(tt.ss); got != tt.wantMin {
+				t.Errorf("Min: got %q, want %q", got, tt.wantMin)
+			}
+		})
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	got := Dedupe([]string{"1.0", "2", "1", "1.0-ga", "2.0.0", "1-cr", "1-rc"})
+	want := []string{"1.0", "2", "1-cr"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
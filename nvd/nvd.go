@@ -0,0 +1,176 @@
+// Package nvd evaluates the version bounds of NVD CPE match criteria.
+//
+// NVD configurations bound the affected versions of a product with
+// versionStartIncluding, versionStartExcluding, versionEndIncluding and
+// versionEndExcluding. The versions are free-form strings copied from vendor
+// advisories, so Evaluate compares them with semantic versioning when every
+// string involved parses as such and falls back to maven tokenization, which
+// accepts any string, otherwise. When the fallback decides a bound by
+// qualifier text alone, the result is reported as ambiguous.
+package nvd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wfscheper/vercmp"
+	"github.com/wfscheper/vercmp/maven"
+	"github.com/wfscheper/vercmp/semver"
+)
+
+// Match is a cpeMatch object from an NVD configuration node.
+type Match struct {
+	Vulnerable            bool   `json:"vulnerable"`
+	Criteria              string `json:"criteria"`
+	VersionStartIncluding string `json:"versionStartIncluding,omitempty"`
+	VersionStartExcluding string `json:"versionStartExcluding,omitempty"`
+	VersionEndIncluding   string `json:"versionEndIncluding,omitempty"`
+	VersionEndExcluding   string `json:"versionEndExcluding,omitempty"`
+}
+
+// Result is the outcome of evaluating a Match against a version.
+type Result struct {
+	// Affected reports whether the version satisfies every bound.
+	Affected bool
+	// Scheme is the vercmp scheme the versions were compared with.
+	Scheme string
+	// Ambiguous reports that the comparison may not reflect the vendor's
+	// intent, and Reason explains why.
+	Ambiguous bool
+	Reason    string
+}
+
+// bound is a single version bound of a Match.
+type bound struct {
+	field   string
+	version string
+	accept  func(cmp int) bool
+}
+
+func (m *Match) bounds() []bound {
+	var bounds []bound
+	add := func(field, version string, accept func(int) bool) {
+		if version != "" {
+			bounds = append(bounds, bound{field, version, accept})
+		}
+	}
+	add("versionStartIncluding", m.VersionStartIncluding, func(c int) bool { return c >= 0 })
+	add("versionStartExcluding", m.VersionStartExcluding, func(c int) bool { return c > 0 })
+	add("versionEndIncluding", m.VersionEndIncluding, func(c int) bool { return c <= 0 })
+	add("versionEndExcluding", m.VersionEndExcluding, func(c int) bool { return c < 0 })
+	if len(bounds) == 0 {
+		// Without explicit bounds the criteria names a single version.
+		switch v := cpeVersion(m.Criteria); v {
+		case "*":
+		case "", "-":
+			bounds = append(bounds, bound{"criteria", v, nil})
+		default:
+			add("criteria", v, func(c int) bool { return c == 0 })
+		}
+	}
+	return bounds
+}
+
+// Evaluate reports whether version falls within m's bounds. A criteria that
+// has no bounds matches its CPE version exactly, or any version if that is
+// "*".
+func (m *Match) Evaluate(version string) (Result, error) {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return Result{}, fmt.Errorf("nvd: empty version")
+	}
+	bounds := m.bounds()
+	for _, b := range bounds {
+		if b.accept == nil {
+			return Result{}, fmt.Errorf("nvd: criteria %q has no comparable version", m.Criteria)
+		}
+	}
+
+	r := Result{Affected: true, Scheme: scheme(version, bounds)}
+	for _, b := range bounds {
+		var c int
+		if r.Scheme == vercmp.SemVer {
+			c = semver.Vercmp(version, b.version)
+		} else {
+			c = maven.Vercmp(version, b.version)
+			if !r.Ambiguous {
+				r.Ambiguous, r.Reason = ambiguous(version, b, c)
+			}
+		}
+		if !b.accept(c) {
+			r.Affected = false
+		}
+	}
+	return r, nil
+}
+
+// scheme picks semver when version and every bound parse as semantic
+// versions, and maven otherwise.
+func scheme(version string, bounds []bound) string {
+	if !isSemVer(version) {
+		return vercmp.Maven
+	}
+	for _, b := range bounds {
+		if !isSemVer(b.version) {
+			return vercmp.Maven
+		}
+	}
+	return vercmp.SemVer
+}
+
+func isSemVer(s string) bool {
+	_, err := semver.New(s)
+	return err == nil
+}
+
+// ambiguous reports whether the maven comparison c of version against b was
+// decided by qualifier text rather than by numbers.
+func ambiguous(version string, b bound, c int) (bool, string) {
+	vp, bp := numericPrefix(version), numericPrefix(b.version)
+	switch {
+	case vp == "":
+		return true, fmt.Sprintf("version %q does not start with a number", version)
+	case bp == "":
+		return true, fmt.Sprintf("%s %q does not start with a number", b.field, b.version)
+	case c != 0 && maven.Vercmp(vp, bp) == 0:
+		return true, fmt.Sprintf("%s %q and version %q differ only after their numeric prefix",
+			b.field, b.version, version)
+	}
+	return false, ""
+}
+
+// numericPrefix returns the leading run of digits and dots of s, with
+// trailing dots removed.
+func numericPrefix(s string) string {
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return r != '.' && (r < '0' || r > '9')
+	})
+	if i < 0 {
+		i = len(s)
+	}
+	return strings.TrimRight(s[:i], ".")
+}
+
+// cpeVersion returns the unescaped version component of a CPE 2.3 formatted
+// string, or "" if criteria is not one.
+func cpeVersion(criteria string) string {
+	var fields []string
+	var field strings.Builder
+	for i := 0; i < len(criteria); i++ {
+		switch c := criteria[i]; {
+		case c == '\\' && i+1 < len(criteria):
+			i++
+			field.WriteByte(criteria[i])
+		case c == ':':
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(c)
+		}
+	}
+	fields = append(fields, field.String())
+	if len(fields) < 6 || fields[0] != "cpe" || fields[1] != "2.3" {
+		return ""
+	}
+	return fields[5]
+}
@@ -0,0 +1,189 @@
+package nvd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/wfscheper/vercmp"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name      string
+		match     Match
+		version   string
+		affected  bool
+		scheme    string
+		ambiguous bool
+	}{
+		{
+			name:     "semver inside",
+			match:    Match{VersionStartIncluding: "2.0.0", VersionEndExcluding: "2.15.0"},
+			version:  "2.14.1",
+			affected: true,
+			scheme:   vercmp.SemVer,
+		},
+		{
+			name:    "semver start excluded",
+			match:   Match{VersionStartExcluding: "2.0.0", VersionEndExcluding: "2.15.0"},
+			version: "2.0.0",
+			scheme:  vercmp.SemVer,
+		},
+		{
+			name:     "semver end included",
+			match:    Match{VersionEndIncluding: "2.15.0"},
+			version:  "2.15.0",
+			affected: true,
+			scheme:   vercmp.SemVer,
+		},
+		{
+			name:    "semver end excluded",
+			match:   Match{VersionEndExcluding: "2.15.0"},
+			version: "2.15.0",
+			scheme:  vercmp.SemVer,
+		},
+		{
+			name:     "two component versions fall back to maven",
+			match:    Match{VersionStartIncluding: "2.0", VersionEndExcluding: "2.15"},
+			version:  "2.9.1",
+			affected: true,
+			scheme:   vercmp.Maven,
+		},
+		{
+			name:    "maven trailing zeros",
+			match:   Match{VersionEndExcluding: "2.15"},
+			version: "2.15.0",
+			scheme:  vercmp.Maven,
+		},
+		{
+			name:      "qualifier decides",
+			match:     Match{VersionEndExcluding: "2.15.0"},
+			version:   "2.15.0-rc1",
+			affected:  true,
+			scheme:    vercmp.Maven,
+			ambiguous: true,
+		},
+		{
+			name:      "letter suffix decides",
+			match:     Match{VersionEndIncluding: "1.0.2"},
+			version:   "1.0.2k",
+			scheme:    vercmp.Maven,
+			ambiguous: true,
+		},
+		{
+			name:     "qualifier irrelevant",
+			match:    Match{VersionEndExcluding: "3.0"},
+			version:  "2.15.0-rc1",
+			affected: true,
+			scheme:   vercmp.Maven,
+		},
+		{
+			name:      "no numeric prefix",
+			match:     Match{VersionEndExcluding: "3.0"},
+			version:   "r12",
+			affected:  true,
+			scheme:    vercmp.Maven,
+			ambiguous: true,
+		},
+		{
+			name:     "criteria version",
+			match:    Match{Criteria: "cpe:2.3:a:example:server:2.4.1:*:*:*:*:*:*:*"},
+			version:  "2.4.1",
+			affected: true,
+			scheme:   vercmp.SemVer,
+		},
+		{
+			name:    "criteria version mismatch",
+			match:   Match{Criteria: "cpe:2.3:a:example:server:2.4.1:*:*:*:*:*:*:*"},
+			version: "2.4.2",
+			scheme:  vercmp.SemVer,
+		},
+		{
+			name:     "criteria escaped version",
+			match:    Match{Criteria: `cpe:2.3:a:example:server:2.4\:1:*:*:*:*:*:*:*`},
+			version:  "2.4:1",
+			affected: true,
+			scheme:   vercmp.Maven,
+		},
+		{
+			name:     "criteria any version",
+			match:    Match{Criteria: "cpe:2.3:a:example:server:*:*:*:*:*:*:*:*"},
+			version:  "0.1",
+			affected: true,
+			scheme:   vercmp.Maven,
+		},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.match.Evaluate(tt.version)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got.Affected != tt.affected {
+				t.Errorf("Affected: got %v, want %v", got.Affected, tt.affected)
+			}
+			if got.Scheme != tt.scheme {
+				t.Errorf("Scheme: got %q, want %q", got.Scheme, tt.scheme)
+			}
+			if got.Ambiguous != tt.ambiguous {
+				t.Errorf("Ambiguous: got %v (%s), want %v", got.Ambiguous, got.Reason, tt.ambiguous)
+			}
+			if got.Ambiguous == (got.Reason == "") {
+				t.Errorf("Reason: got %q with Ambiguous %v", got.Reason, got.Ambiguous)
+			}
+		})
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		match   Match
+		version string
+	}{
+		{"empty version", Match{VersionEndExcluding: "1.0"}, " "},
+		{"not applicable", Match{Criteria: "cpe:2.3:a:example:server:-:*:*:*:*:*:*:*"}, "1.0"},
+		{"not a cpe", Match{Criteria: "example"}, "1.0"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.match.Evaluate(tt.version); err == nil {
+				t.Error("got nil, want error")
+			}
+		})
+	}
+}
+
+func TestMatchJSON(t *testing.T) {
+	data := []byte(`{
+		"vulnerable": true,
+		"criteria": "cpe:2.3:a:apache:log4j:*:*:*:*:*:*:*:*",
+		"versionStartIncluding": "2.0.1",
+		"versionEndExcluding": "2.3.1",
+		"matchCriteriaId": "03FA5E81-F9C0-403E-8A4B-E4284E4E7B72"
+	}`)
+	var m Match
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	want := Match{
+		Vulnerable:            true,
+		Criteria:              "cpe:2.3:a:apache:log4j:*:*:*:*:*:*:*:*",
+		VersionStartIncluding: "2.0.1",
+		VersionEndExcluding:   "2.3.1",
+	}
+	if m != want {
+		t.Fatalf("got %+v, want %+v", m, want)
+	}
+	r, err := m.Evaluate("2.3.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Affected {
+		t.Error("got false, want true")
+	}
+}
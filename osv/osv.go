@@ -0,0 +1,239 @@
+// Package osv evaluates the affected ranges of advisories in the Open Source
+// Vulnerability format used by OSV.dev.
+//
+// See https://ossf.github.io/osv-schema/ for the format. Ranges of type SEMVER
+// are compared by the rules of Semantic Versioning 2.0.0, and ranges of type
+// ECOSYSTEM are compared with the scheme that Ecosystems maps the package's
+// ecosystem to. Ranges of type GIT cannot be evaluated against a version
+// string.
+package osv
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/wfscheper/vercmp"
+)
+
+// Range types.
+const (
+	TypeSemVer    = "SEMVER"
+	TypeEcosystem = "ECOSYSTEM"
+	TypeGit       = "GIT"
+)
+
+// Ecosystems maps OSV ecosystem names to the vercmp schemes that compare
+// their versions. Callers may add entries before evaluating advisories.
+var Ecosystems = map[string]string{
+	"Maven": vercmp.Maven,
+}
+
+// Vulnerability is an OSV advisory.
+type Vulnerability struct {
+	ID       string     `json:"id"`
+	Modified string     `json:"modified,omitempty"`
+	Aliases  []string   `json:"aliases,omitempty"`
+	Summary  string     `json:"summary,omitempty"`
+	Affected []Affected `json:"affected"`
+}
+
+// Affected lists the versions of a package affected by a vulnerability.
+type Affected struct {
+	Package  Package  `json:"package"`
+	Ranges   []Range  `json:"ranges,omitempty"`
+	Versions []string `json:"versions,omitempty"`
+}
+
+// Package identifies a package within an ecosystem.
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Purl      string `json:"purl,omitempty"`
+}
+
+// Range is a sequence of events that introduce and fix a vulnerability.
+type Range struct {
+	Type   string  `json:"type"`
+	Repo   string  `json:"repo,omitempty"`
+	Events []Event `json:"events"`
+}
+
+// Event is a single change in whether versions are affected. Exactly one of
+// its fields is set.
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// version returns the version the event applies to.
+func (e Event) version() string {
+	switch {
+	case e.Introduced != "":
+		return e.Introduced
+	case e.Fixed != "":
+		return e.Fixed
+	case e.LastAffected != "":
+		return e.LastAffected
+	}
+	return e.Limit
+}
+
+// Parse parses an OSV advisory.
+func Parse(data []byte) (*Vulnerability, error) {
+	v := new(Vulnerability)
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, fmt.Errorf("osv: %v", err)
+	}
+	return v, nil
+}
+
+// Load reads and parses the OSV advisory in the file at path.
+func Load(path string) (*Vulnerability, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	v, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return v, nil
+}
+
+// Affects reports whether version of the package name in ecosystem is
+// affected by v.
+func (v *Vulnerability) Affects(ecosystem, name, version string) (bool, error) {
+	for _, a := range v.Affected {
+		if baseEcosystem(a.Package.Ecosystem) != baseEcosystem(ecosystem) || a.Package.Name != name {
+			continue
+		}
+		ok, err := a.Affects(version)
+		if ok || err != nil {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// Affects reports whether version is listed in a's versions or falls within
+// any of a's ranges.
+func (a *Affected) Affects(version string) (bool, error) {
+	for _, v := range a.Versions {
+		if v == version {
+			return true, nil
+		}
+	}
+	for _, r := range a.Ranges {
+		cmp, err := a.compareFunc(r.Type)
+		if err != nil {
+			return false, err
+		}
+		ok, err := r.Affects(cmp, version)
+		if ok || err != nil {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// compareFunc returns the comparison for ranges of type typ.
+func (a *Affected) compareFunc(typ string) (vercmp.CompareFunc, error) {
+	var name string
+	switch typ {
+	case TypeSemVer:
+		name = vercmp.SemVer2
+	case TypeEcosystem:
+		var ok bool
+		if name, ok = Ecosystems[baseEcosystem(a.Package.Ecosystem)]; !ok {
+			return nil, fmt.Errorf("osv: unsupported ecosystem %q", a.Package.Ecosystem)
+		}
+	default:
+		return nil, fmt.Errorf("osv: unsupported range type %q", typ)
+	}
+	cmp, ok := vercmp.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("osv: unknown scheme %q", name)
+	}
+	return cmp, nil
+}
+
+// Affects reports whether version falls within r, comparing versions with
+// cmp. Events are applied in version order: an introduced event at or below
+// version marks it affected, and a fixed event at or below version, or a
+// last_affected event below it, marks it unaffected again. If r has limit
+// events, version must also be below one of them.
+func (r *Range) Affects(cmp vercmp.CompareFunc, version string) (bool, error) {
+	events := append([]Event(nil), r.Events...)
+	var err error
+	sort.SliceStable(events, func(i, j int) bool {
+		c, cerr := compareEvents(cmp, events[i], events[j])
+		if cerr != nil && err == nil {
+			err = cerr
+		}
+		return c < 0
+	})
+	if err != nil {
+		return false, err
+	}
+
+	affected := false
+	limited, belowLimit := false, false
+	for _, e := range events {
+		if e.Introduced == "0" {
+			affected = true
+			continue
+		}
+		c, err := cmp(version, e.version())
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case e.Introduced != "":
+			if c >= 0 {
+				affected = true
+			}
+		case e.Fixed != "":
+			if c >= 0 {
+				affected = false
+			}
+		case e.LastAffected != "":
+			if c > 0 {
+				affected = false
+			}
+		case e.Limit != "":
+			limited = true
+			if c < 0 {
+				belowLimit = true
+			}
+		}
+	}
+	return affected && (!limited || belowLimit), nil
+}
+
+// compareEvents orders events by version, with an introduced event of "0"
+// before all others.
+func compareEvents(cmp vercmp.CompareFunc, a, b Event) (int, error) {
+	aZero, bZero := a.Introduced == "0", b.Introduced == "0"
+	switch {
+	case aZero && bZero:
+		return 0, nil
+	case aZero:
+		return -1, nil
+	case bZero:
+		return 1, nil
+	}
+	return cmp(a.version(), b.version())
+}
+
+// baseEcosystem strips any release suffix, such as ":10" in "Debian:10".
+func baseEcosystem(ecosystem string) string {
+	if i := strings.IndexByte(ecosystem, ':'); i >= 0 {
+		return ecosystem[:i]
+	}
+	return ecosystem
+}
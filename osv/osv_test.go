@@ -0,0 +1,128 @@
+package osv
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/wfscheper/vercmp"
+)
+
+func TestAffects(t *testing.T) {
+	tests := []struct {
+		file, ecosystem, name, version string
+		want                           bool
+	}{
+		{"OSV-TEST-MAVEN-1", "Maven", "org.example:example-core", "2.0-alpha1", false},
+		{"OSV-TEST-MAVEN-1", "Maven", "org.example:example-core", "2.0-beta9", true},
+		{"OSV-TEST-MAVEN-1", "Maven", "org.example:example-core", "2.0-rc2", true},
+		{"OSV-TEST-MAVEN-1", "Maven", "org.example:example-core", "2.12.1", true},
+		{"OSV-TEST-MAVEN-1", "Maven", "org.example:example-core", "2.12.2", false},
+		{"OSV-TEST-MAVEN-1", "Maven", "org.example:example-core", "2.12.4", false},
+		{"OSV-TEST-MAVEN-1", "Maven", "org.example:example-core", "2.13.0", true},
+		{"OSV-TEST-MAVEN-1", "Maven", "org.example:example-core", "2.15.0-SNAPSHOT", true},
+		{"OSV-TEST-MAVEN-1", "Maven", "org.example:example-core", "2.15.0", false},
+		{"OSV-TEST-MAVEN-1", "Maven", "org.example:example-core", "2.17.1", false},
+		{"OSV-TEST-MAVEN-1", "Maven", "org.example:example-api", "1.0", true},
+		{"OSV-TEST-MAVEN-1", "Maven", "org.example:example-api", "1.4.0", true},
+		{"OSV-TEST-MAVEN-1", "Maven", "org.example:example-api", "1.4.1", false},
+		{"OSV-TEST-MAVEN-1", "Maven", "org.example:other", "2.13.0", false},
+		{"OSV-TEST-MAVEN-1", "npm", "org.example:example-core", "2.13.0", false},
+		{"OSV-TEST-SEMVER-1", "Go", "example.com/server", "0.0.1", true},
+		{"OSV-TEST-SEMVER-1", "Go", "example.com/server", "1.4.1", true},
+		{"OSV-TEST-SEMVER-1", "Go", "example.com/server", "1.4.2-rc.1", true},
+		{"OSV-TEST-SEMVER-1", "Go", "example.com/server", "1.4.2", false},
+		{"OSV-TEST-SEMVER-1", "Go", "example.com/server", "2.0.0", true},
+		{"OSV-TEST-SEMVER-1", "Go", "example.com/server", "2.3.1", true},
+		{"OSV-TEST-SEMVER-1", "Go", "example.com/server", "2.3.2", false},
+		{"OSV-TEST-SEMVER-1", "Go", "example.com/limited", "0.9.0", false},
+		{"OSV-TEST-SEMVER-1", "Go", "example.com/limited", "1.2.0", true},
+		{"OSV-TEST-SEMVER-1", "Go", "example.com/limited", "1.5.0", false},
+		{"OSV-TEST-UNSUPPORTED-1", "PyPI", "example", "0.9", true},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.file+" "+tt.name+" "+tt.version, func(t *testing.T) {
+			v, err := Load(filepath.Join("testdata", tt.file+".json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := v.Affects(tt.ecosystem, tt.name, tt.version)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAffectsErrors(t *testing.T) {
+	tests := []struct {
+		file, ecosystem, name, version string
+	}{
+		{"OSV-TEST-UNSUPPORTED-1", "PyPI", "example", "0.9.1"},
+		{"OSV-TEST-UNSUPPORTED-1", "Go", "example.com/git", "1.0.0"},
+		{"OSV-TEST-SEMVER-1", "Go", "example.com/server", "1.4"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.file+" "+tt.name+" "+tt.version, func(t *testing.T) {
+			v, err := Load(filepath.Join("testdata", tt.file+".json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := v.Affects(tt.ecosystem, tt.name, tt.version); err == nil {
+				t.Error("got nil, want error")
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	v, err := Load(filepath.Join("testdata", "OSV-TEST-MAVEN-1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.ID != "OSV-TEST-MAVEN-1" || len(v.Affected) != 2 || len(v.Affected[0].Ranges[0].Events) != 4 {
+		t.Errorf("got %+v", v)
+	}
+	if e := v.Affected[1].Ranges[0].Events[1]; e.LastAffected != "1.4" {
+		t.Errorf("got %+v, want last_affected 1.4", e)
+	}
+
+	if _, err := Parse([]byte("{")); err == nil {
+		t.Error("got nil, want error")
+	}
+	if _, err := Load(filepath.Join("testdata", "missing.json")); err == nil {
+		t.Error("got nil, want error")
+	}
+}
+
+func TestRangeAffectsFunc(t *testing.T) {
+	cmp, _ := vercmp.Lookup(vercmp.Maven)
+	r := Range{Type: TypeEcosystem, Events: []Event{{Introduced: "1.0"}, {Fixed: "1.0.1"}}}
+	tests := map[string]bool{"0.9": false, "1": true, "1.0.0.1": true, "1.0.1": false}
+	for version, want := range tests {
+		got, err := r.Affects(cmp, version)
+		if err != nil {
+			t.Fatalf("got %v, want nil", err)
+		}
+		if got != want {
+			t.Errorf("Affects(%s): got %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestEcosystemSuffix(t *testing.T) {
+	v := &Vulnerability{Affected: []Affected{{
+		Package: Package{Ecosystem: "Maven:central", Name: "a:b"},
+		Ranges:  []Range{{Type: TypeEcosystem, Events: []Event{{Introduced: "0"}, {Fixed: "2.0"}}}},
+	}}}
+	got, err := v.Affects("Maven", "a:b", "1.0")
+	if err != nil || !got {
+		t.Errorf("got %v, %v, want true, nil", got, err)
+	}
+}
@@ -0,0 +1,353 @@
+// Package pom extracts dependency versions from Maven pom.xml files.
+//
+// Versions are interpolated from the properties of the project and of any
+// parent POMs found on the local filesystem, then parsed as maven ranges so
+// that a plain version such as 1.2.3 and a range such as [1.2,2.0) can be
+// checked the same way. Parents are located through their relativePath,
+// which defaults to ../pom.xml; parents that only exist in a remote
+// repository are not fetched.
+package pom
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/wfscheper/vercmp/maven"
+)
+
+// Project is a parsed POM.
+type Project struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+	Packaging  string
+	Parent     *Parent
+	// Properties are the properties declared by this POM, before
+	// interpolation.
+	Properties map[string]string
+	// Dependencies and DependencyManagement are the entries declared by this
+	// POM, with interpolated versions.
+	Dependencies         []Dependency
+	DependencyManagement []Dependency
+
+	// ParentProject is the parent POM found on the filesystem, or nil.
+	ParentProject *Project
+	// Path is the file the project was loaded from, or "" if it was parsed
+	// from a reader.
+	Path string
+}
+
+// Parent is the parent element of a POM.
+type Parent struct {
+	GroupID      string  `xml:"groupId"`
+	ArtifactID   string  `xml:"artifactId"`
+	Version      string  `xml:"version"`
+	RelativePath *string `xml:"relativePath"`
+}
+
+// Dependency is a dependency or managed dependency of a project.
+type Dependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	// Version is the interpolated version requirement. A dependency that
+	// declares no version takes it from the dependency management of the
+	// project or its parents.
+	Version    string `xml:"version"`
+	Type       string `xml:"type"`
+	Classifier string `xml:"classifier"`
+	Scope      string `xml:"scope"`
+	Optional   bool   `xml:"optional"`
+
+	// Range is Version parsed as a maven range. It is nil if no version was
+	// declared or managed.
+	Range *maven.Range `xml:"-"`
+}
+
+// Key returns the groupId:artifactId of d.
+func (d *Dependency) Key() string {
+	return d.GroupID + ":" + d.ArtifactID
+}
+
+// Contains reports whether v satisfies the version requirement of d. A plain
+// version is a soft requirement, which any version satisfies; use
+// Range.Recommended to compare against it.
+func (d *Dependency) Contains(v *maven.Version) bool {
+	return d.Range != nil && d.Range.Contains(v)
+}
+
+// model mirrors the pom.xml elements that Project is built from.
+type model struct {
+	GroupID              string       `xml:"groupId"`
+	ArtifactID           string       `xml:"artifactId"`
+	Version              string       `xml:"version"`
+	Packaging            string       `xml:"packaging"`
+	Parent               *Parent      `xml:"parent"`
+	Properties           properties   `xml:"properties"`
+	Dependencies         []Dependency `xml:"dependencies>dependency"`
+	DependencyManagement []Dependency `xml:"dependencyManagement>dependencies>dependency"`
+}
+
+// properties decodes the arbitrary child elements of <properties>.
+type properties map[string]string
+
+func (p *properties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*p = properties{}
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var s string
+			if err := d.DecodeElement(&s, &t); err != nil {
+				return err
+			}
+			(*p)[t.Name.Local] = strings.TrimSpace(s)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// Parse reads a POM from r. Only the properties of the POM itself are
+// available for interpolation.
+func Parse(r io.Reader) (*Project, error) {
+	p, err := decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("pom: %v", err)
+	}
+	if err := p.interpolate(); err != nil {
+		return nil, fmt.Errorf("pom: %v", err)
+	}
+	return p, nil
+}
+
+// Load reads the POM at path along with the chain of parent POMs that can be
+// found on the filesystem.
+func Load(path string) (*Project, error) {
+	p, err := load(path, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	for q := p; q != nil; q = q.ParentProject {
+		if err := q.interpolate(); err != nil {
+			return nil, fmt.Errorf("pom: %s: %v", q.Path, err)
+		}
+	}
+	return p, nil
+}
+
+func load(path string, seen map[string]bool) (*Project, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if seen[abs] {
+		return nil, fmt.Errorf("pom: %s: parent cycle", path)
+	}
+	seen[abs] = true
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	p, err := decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("pom: %s: %v", path, err)
+	}
+	p.Path = path
+
+	parentPath := p.parentPath()
+	if parentPath == "" {
+		return p, nil
+	}
+	if _, err := os.Stat(parentPath); err != nil {
+		return p, nil
+	}
+	parent, err := load(parentPath, seen)
+	if err != nil {
+		return nil, err
+	}
+	// A POM at the relative path that is not the declared parent is ignored,
+	// as Maven would then look for the parent in a repository.
+	if parent.groupID() == p.Parent.GroupID && parent.ArtifactID == p.Parent.ArtifactID {
+		p.ParentProject = parent
+	}
+	return p, nil
+}
+
+// parentPath returns the filesystem path of p's parent POM, or "" if it has
+// none or it is explicitly not on the filesystem.
+func (p *Project) parentPath() string {
+	if p.Parent == nil {
+		return ""
+	}
+	rel := "../pom.xml"
+	if p.Parent.RelativePath != nil {
+		rel = strings.TrimSpace(*p.Parent.RelativePath)
+	}
+	if rel == "" {
+		return ""
+	}
+	path := filepath.Join(filepath.Dir(p.Path), filepath.FromSlash(rel))
+	if fi, err := os.Stat(path); err == nil && fi.IsDir() {
+		path = filepath.Join(path, "pom.xml")
+	}
+	return path
+}
+
+func decode(r io.Reader) (*Project, error) {
+	var m model
+	if err := xml.NewDecoder(r).Decode(&m); err != nil {
+		return nil, err
+	}
+	for _, deps := range [][]Dependency{m.Dependencies, m.DependencyManagement} {
+		for i := range deps {
+			d := &deps[i]
+			d.GroupID = strings.TrimSpace(d.GroupID)
+			d.ArtifactID = strings.TrimSpace(d.ArtifactID)
+			d.Version = strings.TrimSpace(d.Version)
+			d.Type = strings.TrimSpace(d.Type)
+			d.Classifier = strings.TrimSpace(d.Classifier)
+			d.Scope = strings.TrimSpace(d.Scope)
+			if d.Type == "" {
+				d.Type = "jar"
+			}
+		}
+	}
+	return &Project{
+		GroupID:              strings.TrimSpace(m.GroupID),
+		ArtifactID:           strings.TrimSpace(m.ArtifactID),
+		Version:              strings.TrimSpace(m.Version),
+		Packaging:            strings.TrimSpace(m.Packaging),
+		Parent:               m.Parent,
+		Properties:           m.Properties,
+		Dependencies:         m.Dependencies,
+		DependencyManagement: m.DependencyManagement,
+	}, nil
+}
+
+// groupID returns the groupId of p, which may be inherited from its parent.
+func (p *Project) groupID() string {
+	if p.GroupID == "" && p.Parent != nil {
+		return p.Parent.GroupID
+	}
+	return p.GroupID
+}
+
+// version returns the version of p, which may be inherited from its parent.
+func (p *Project) version() string {
+	if p.Version == "" && p.Parent != nil {
+		return p.Parent.Version
+	}
+	return p.Version
+}
+
+var propertyRe = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// property returns the value of the property name in the context of p.
+// Properties declared closer to p take precedence over those of its parents.
+func (p *Project) property(name string) (string, bool) {
+	switch strings.TrimPrefix(strings.TrimPrefix(name, "project."), "pom.") {
+	case "version":
+		return p.version(), true
+	case "groupId":
+		return p.groupID(), true
+	case "artifactId":
+		return p.ArtifactID, true
+	case "parent.version":
+		if p.Parent != nil {
+			return p.Parent.Version, true
+		}
+	case "parent.groupId":
+		if p.Parent != nil {
+			return p.Parent.GroupID, true
+		}
+	}
+	for q := p; q != nil; q = q.ParentProject {
+		if v, ok := q.Properties[name]; ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// expand replaces the property references in s.
+func (p *Project) expand(s string, expanding map[string]bool) (string, error) {
+	var err error
+	s = propertyRe.ReplaceAllStringFunc(s, func(ref string) string {
+		name := ref[2 : len(ref)-1]
+		if err != nil {
+			return ref
+		}
+		if expanding[name] {
+			err = fmt.Errorf("recursive property ${%s}", name)
+			return ref
+		}
+		v, ok := p.property(name)
+		if !ok {
+			err = fmt.Errorf("unresolved property ${%s}", name)
+			return ref
+		}
+		expanding[name] = true
+		v, err = p.expand(v, expanding)
+		delete(expanding, name)
+		return v
+	})
+	return s, err
+}
+
+// managedVersion returns the version that the dependency management of p or
+// its parents gives d.
+func (p *Project) managedVersion(d *Dependency) string {
+	for q := p; q != nil; q = q.ParentProject {
+		for _, m := range q.DependencyManagement {
+			if m.Key() == d.Key() && m.Version != "" && m.Type == d.Type && m.Classifier == d.Classifier {
+				return m.Version
+			}
+		}
+	}
+	return ""
+}
+
+// interpolate expands the versions of p's dependencies and parses them.
+// Parents must be interpolated after their children, since a child's
+// managed versions are read from its parent before interpolation.
+func (p *Project) interpolate() error {
+	resolve := func(d *Dependency, managed bool) error {
+		if d.Version == "" && !managed {
+			d.Version = p.managedVersion(d)
+		}
+		if d.Version == "" {
+			return nil
+		}
+		v, err := p.expand(d.Version, map[string]bool{})
+		if err != nil {
+			return fmt.Errorf("%s: %v", d.Key(), err)
+		}
+		r, err := maven.ParseRange(v)
+		if err != nil {
+			return fmt.Errorf("%s: %v", d.Key(), err)
+		}
+		d.Version, d.Range = v, r
+		return nil
+	}
+	for i := range p.DependencyManagement {
+		if err := resolve(&p.DependencyManagement[i], true); err != nil {
+			return err
+		}
+	}
+	for i := range p.Dependencies {
+		if err := resolve(&p.Dependencies[i], false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
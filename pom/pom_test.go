@@ -0,0 +1,173 @@
+package pom
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wfscheper/vercmp/maven"
+)
+
+func TestLoad(t *testing.T) {
+	p, err := Load(filepath.Join("testdata", "parent", "child", "pom.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ParentProject == nil || p.ParentProject.ArtifactID != "example-parent" {
+		t.Fatalf("got parent %v, want example-parent", p.ParentProject)
+	}
+
+	tests := []struct {
+		key, version, scope string
+		optional            bool
+	}{
+		{"org.apache.logging.log4j:log4j-core", "2.17.1", "", false},
+		{"com.google.guava:guava", "32.0.0-jre", "", false},
+		{"com.fasterxml.jackson.core:jackson-databind", "[2.13,2.14)", "", false},
+		{"org.example:example-lib", "2.1.0", "", false},
+		{"junit:junit", "4.13.2", "test", false},
+		{"org.example:example-unmanaged", "", "", true},
+	}
+	if len(p.Dependencies) != len(tests) {
+		t.Fatalf("got %d dependencies, want %d", len(p.Dependencies), len(tests))
+	}
+	for i, tt := range tests {
+		d := p.Dependencies[i]
+		if d.Key() != tt.key {
+			t.Errorf("dependency %d: got %s, want %s", i, d.Key(), tt.key)
+			continue
+		}
+		if d.Version != tt.version {
+			t.Errorf("%s: got version %q, want %q", tt.key, d.Version, tt.version)
+		}
+		if d.Scope != tt.scope || d.Optional != tt.optional || d.Type != "jar" {
+			t.Errorf("%s: got scope %q optional %v type %q", tt.key, d.Scope, d.Optional, d.Type)
+		}
+		if (d.Range == nil) != (tt.version == "") {
+			t.Errorf("%s: got range %v", tt.key, d.Range)
+		}
+	}
+
+	if got := p.DependencyManagement[0].Version; got != "4.13.2" {
+		t.Errorf("got managed version %q, want 4.13.2", got)
+	}
+	if got := p.ParentProject.DependencyManagement[0].Version; got != "2.17.1" {
+		t.Errorf("got parent managed version %q, want 2.17.1", got)
+	}
+	// The parent interpolates guava.version in its own context.
+	if got := p.ParentProject.DependencyManagement[1].Version; got != "31.1-jre" {
+		t.Errorf("got parent managed version %q, want 31.1-jre", got)
+	}
+}
+
+func TestDependencyRange(t *testing.T) {
+	p, err := Load(filepath.Join("testdata", "parent", "child", "pom.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	deps := map[string]Dependency{}
+	for _, d := range p.Dependencies {
+		deps[d.Key()] = d
+	}
+
+	jackson := deps["com.fasterxml.jackson.core:jackson-databind"]
+	for v, want := range map[string]bool{"2.12.7": false, "2.13.4": true, "2.14.0": false} {
+		if got := jackson.Contains(maven.New(v)); got != want {
+			t.Errorf("jackson-databind contains %s: got %v, want %v", v, got, want)
+		}
+	}
+
+	log4j := deps["org.apache.logging.log4j:log4j-core"]
+	if log4j.Range.Recommended == nil || log4j.Range.Recommended.Compare(maven.New("2.17.0")) <= 0 {
+		t.Errorf("log4j-core: got %v, want a recommended version newer than 2.17.0", log4j.Range)
+	}
+
+	if unmanaged := deps["org.example:example-unmanaged"]; unmanaged.Contains(maven.New("1.0")) {
+		t.Error("unmanaged dependency: got true, want false")
+	}
+}
+
+func TestLoadRelativePathDirectory(t *testing.T) {
+	p, err := Load(filepath.Join("testdata", "other", "pom.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ParentProject == nil {
+		t.Fatal("got nil parent")
+	}
+	want := []string{"3.0.0-SNAPSHOT", "2.1.0"}
+	for i, d := range p.Dependencies {
+		if d.Version != want[i] {
+			t.Errorf("%s: got %q, want %q", d.Key(), d.Version, want[i])
+		}
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	_, err := Load(filepath.Join("testdata", "parent", "bad", "pom.xml"))
+	if err == nil || !strings.Contains(err.Error(), "${missing.version}") {
+		t.Errorf("got %v, want unresolved property error", err)
+	}
+	if _, err := Load(filepath.Join("testdata", "missing", "pom.xml")); err == nil {
+		t.Error("missing file: got nil, want error")
+	}
+}
+
+func TestParse(t *testing.T) {
+	const doc = `<project>
+  <groupId>org.example</groupId>
+  <artifactId>example</artifactId>
+  <version>1.0</version>
+  <parent>
+    <groupId>org.example</groupId>
+    <artifactId>example-parent</artifactId>
+    <version>1</version>
+    <relativePath/>
+  </parent>
+  <properties>
+    <a.version>${b.version}</a.version>
+    <b.version>1.${project.version}</b.version>
+  </properties>
+  <dependencies>
+    <dependency>
+      <groupId>org.example</groupId>
+      <artifactId>a</artifactId>
+      <version>${a.version}</version>
+      <type>pom</type>
+    </dependency>
+  </dependencies>
+</project>`
+	p, err := Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Dependencies[0].Version; got != "1.1.0" {
+		t.Errorf("got %q, want 1.1.0", got)
+	}
+	if got := p.Dependencies[0].Type; got != "pom" {
+		t.Errorf("got type %q, want pom", got)
+	}
+	if p.parentPath() != "" {
+		t.Errorf("got parent path %q, want empty", p.parentPath())
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name, doc string
+	}{
+		{"invalid xml", "<project>"},
+		{"recursive property", `<project><properties><a>${b}</a><b>${a}</b></properties>
+			<dependencies><dependency><version>${a}</version></dependency></dependencies></project>`},
+		{"invalid range", `<project><dependencies><dependency><version>[1.0</version></dependency></dependencies></project>`},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.doc)); err == nil {
+				t.Error("got nil, want error")
+			}
+		})
+	}
+}
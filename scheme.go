@@ -0,0 +1,122 @@
+package vercmp
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/wfscheper/vercmp/gradle"
+	"github.com/wfscheper/vercmp/ivy"
+	"github.com/wfscheper/vercmp/semver"
+	"github.com/wfscheper/vercmp/semver2"
+)
+
+// Names of the built-in version schemes.
+const (
+	Gradle = "gradle"
+	Ivy    = "ivy"
+	Maven  = "maven"
+	SemVer = "semver"
+	// SemVer2 is Semantic Versioning 2.0.0, as used by npm, Cargo and Go
+	// modules. SemVer is PBR's Semantic Versioning 3.0.0.
+	SemVer2 = "semver2"
+)
+
+// CompareFunc compares two version strings, a and b, and returns a negative
+// integer if a is older than b, 0 if a is the same as b, and a positive
+// integer if a is newer than b. It returns an error if a or b is not a valid
+// version.
+type CompareFunc func(a, b string) (int, error)
+
+var (
+	schemesMu sync.RWMutex
+	schemes   = map[string]CompareFunc{
+		Gradle:  compareGradle,
+		Ivy:     compareIvy,
+		Maven:   compareMaven,
+		SemVer:  compareSemVer,
+		SemVer2: semver2.Vercmp,
+	}
+)
+
+// Register makes a version scheme available under name. If Register is called
+// twice with the same name or if f is nil, it panics.
+func Register(name string, f CompareFunc) {
+	schemesMu.Lock()
+	defer schemesMu.Unlock()
+	if f == nil {
+		panic("vercmp: Register compare func is nil")
+	}
+	if _, dup := schemes[name]; dup {
+		panic("vercmp: Register called twice for scheme " + name)
+	}
+	schemes[name] = f
+}
+
+// Lookup returns the compare func of the scheme registered under name.
+func Lookup(name string) (CompareFunc, bool) {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+	f, ok := schemes[name]
+	return f, ok
+}
+
+// Schemes returns a sorted list of the names of the registered schemes.
+func Schemes() []string {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Compare compares a and b using the scheme registered under name.
+func Compare(name, a, b string) (int, error) {
+	f, ok := Lookup(name)
+	if !ok {
+		return 0, fmt.Errorf("vercmp: unknown scheme %q", name)
+	}
+	return f(a, b)
+}
+
+func compareGradle(a, b string) (int, error) {
+	return gradle.Vercmp(a, b), nil
+}
+
+func compareIvy(a, b string) (int, error) {
+	return ivy.Vercmp(a, b), nil
+}
+
+func compareMaven(a, b string) (int, error) {
+	return MavenVerCmp(a, b), nil
+}
+
+func compareSemVer(a, b string) (int, error) {
+	aVer, err := parseSemVer(a)
+	if err != nil {
+		return 0, err
+	}
+	bVer, err := parseSemVer(b)
+	if err != nil {
+		return 0, err
+	}
+	return semver.Vercmp(aVer, bVer), nil
+}
+
+// parseSemVer parses s through semver's parse cache, so that invalid versions
+// return an error rather than make semver.Vercmp panic.
+func parseSemVer(s string) (*semver.Version, error) {
+	c := semver.ParseCache()
+	if v, ok := c.Get(s); ok {
+		return v.(*semver.Version), nil
+	}
+	v, err := semver.New(s)
+	if err != nil {
+		return nil, err
+	}
+	c.Add(s, v)
+	return v, nil
+}
@@ -0,0 +1,78 @@
+package vercmp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		scheme, a, b string
+		want         int
+		wantErr      bool
+	}{
+		{Gradle, "1.0-dev", "1.0-alpha", -1, false},
+		{Gradle, "1.0", "1.0.0", -1, false},
+		{Ivy, "1.0-rc1", "1.0-SNAPSHOT", 1, false},
+		{Maven, "1.0", "1", 0, false},
+		{Maven, "1.0-rc1", "1.0", -1, false},
+		{SemVer, "1.2.3", "1.2.3.rc1", 1, false},
+		{SemVer, "1.2", "1.2.3", 0, true},
+		{SemVer, "1.2.3", "1.2", 0, true},
+		{SemVer2, "1.0.0-beta.2", "1.0.0-beta.11", -1, false},
+		{SemVer2, "v1.2.3", "1.2.3+build", 0, false},
+		{SemVer2, "1.2.3.rc1", "1.2.3", 0, true},
+		{"unknown", "1", "1", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := Compare(tt.scheme, tt.a, tt.b)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Compare(%s, %s, %s): got nil, want error", tt.scheme, tt.a, tt.b)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Compare(%s, %s, %s): got %v, want nil", tt.scheme, tt.a, tt.b, err)
+		}
+		if got < 0 {
+			got = -1
+		} else if got > 0 {
+			got = 1
+		}
+		if got != tt.want {
+			t.Errorf("Compare(%s, %s, %s): got %d, want %d", tt.scheme, tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	byLength := func(a, b string) (int, error) {
+		return len(a) - len(b), nil
+	}
+	Register("test-length", byLength)
+
+	if got, err := Compare("test-length", "aaa", "b"); err != nil || got != 2 {
+		t.Errorf("got %d, %v, want 2, nil", got, err)
+	}
+	if _, ok := Lookup("test-length"); !ok {
+		t.Error("got false, want true")
+	}
+	want := []string{Gradle, Ivy, Maven, SemVer, SemVer2, "test-length"}
+	if got := Schemes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	for _, f := range []CompareFunc{byLength, nil} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil || !strings.HasPrefix(r.(string), "vercmp: ") {
+					t.Errorf("got %v, want panic", r)
+				}
+			}()
+			Register("test-length", f)
+		}()
+	}
+}
@@ -0,0 +1,89 @@
+package semver
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/wfscheper/vercmp/internal/benchtest"
+)
+
+// benchVersions returns n pseudo-random semantic versions, the same ones on
+// every call.
+func benchVersions(n int) []string {
+	suffixes := []string{"", "", "", ".a1", ".b2", ".rc1", ".dev3", ".rc2.dev1"}
+	r := rand.New(rand.NewSource(1))
+	vs := make([]string, n)
+	for i := range vs {
+		vs[i] = fmt.Sprintf("%d.%d.%d%s", r.Intn(5), r.Intn(20), r.Intn(50), suffixes[r.Intn(len(suffixes))])
+	}
+	return vs
+}
+
+func BenchmarkParse(b *testing.B) {
+	vs := benchVersions(1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		New(vs[i%len(vs)])
+	}
+}
+
+func BenchmarkComparePreparsed(b *testing.B) {
+	vs, _ := NewVersions(benchVersions(1001))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		j := i % (vs.Len() - 1)
+		vs.At(j).Compare(vs.At(j + 1))
+	}
+}
+
+func BenchmarkCompareStrings(b *testing.B) {
+	vs := benchVersions(1001)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		j := i % (len(vs) - 1)
+		Vercmp(vs[j], vs[j+1])
+	}
+}
+
+func BenchmarkCompareStringsCached(b *testing.B) {
+	defer benchtest.EnableCache(ParseCache(), 4096)()
+	BenchmarkCompareStrings(b)
+}
+
+func BenchmarkSort10k(b *testing.B) {
+	vs := benchVersions(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sort(vs)
+	}
+}
+
+func BenchmarkSort10kPreparsed(b *testing.B) {
+	vs, _ := NewVersions(benchVersions(10000))
+	work := Versions{make([]*Version, vs.Len()), make([]string, vs.Len())}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(work.versions, vs.versions)
+		copy(work.originals, vs.originals)
+		sort.Sort(work)
+	}
+}
+
+// TestAllocs guards the allocations of the benchmarked operations.
+func TestAllocs(t *testing.T) {
+	a, _ := New("1.2.3.a5.dev6")
+	b, _ := New("1.2.3.a5.dev7")
+	benchtest.Allocs(t, "New", 2, func() { New("1.2.3.a5.dev6") })
+	benchtest.Allocs(t, "New release", 2, func() { New("1.2.3") })
+	benchtest.Allocs(t, "Compare", 0, func() { a.Compare(b) })
+	benchtest.Allocs(t, "Vercmp strings", 4, func() { Vercmp("1.2.3.a5.dev6", "1.2.3.a5.dev7") })
+	defer benchtest.EnableCache(ParseCache(), 4096)()
+	benchtest.Allocs(t, "Vercmp strings, cached", 0, func() { Vercmp("1.2.3.a5.dev6", "1.2.3.a5.dev7") })
+}
@@ -0,0 +1,124 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// keyNames name the comparison keys of a Version, in the order they are
+// compared.
+var keyNames = [7]string{
+	"major", "minor", "patch", "release stage", "pre-release type", "pre-release number", "dev count",
+}
+
+// Explanation describes why two versions compare the way they do.
+type Explanation struct {
+	A, B string
+	// AKeys and BKeys are the comparison keys of A and B: major, minor and
+	// patch, whether the version is a dev build of a final release (0) or
+	// not (1), the pre-release type rank, the pre-release number, and the
+	// dev count, which is maxInt for versions that are not dev builds.
+	AKeys, BKeys [7]int
+	// Result is negative if A is older than B, 0 if they are equal, and
+	// positive if A is newer than B.
+	Result int
+	// Key is the index of the first key that differs, or -1 if the versions
+	// are equal.
+	Key int
+	// Reason explains the decision in words.
+	Reason string
+}
+
+// String returns a multi-line report of e.
+func (e *Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "a: %s -> %s\n", e.A, formatKeys(e.AKeys))
+	fmt.Fprintf(&b, "b: %s -> %s\n", e.B, formatKeys(e.BKeys))
+	switch {
+	case e.Result < 0:
+		fmt.Fprintf(&b, "%s < %s\n", e.A, e.B)
+	case e.Result > 0:
+		fmt.Fprintf(&b, "%s > %s\n", e.A, e.B)
+	default:
+		fmt.Fprintf(&b, "%s == %s\n", e.A, e.B)
+	}
+	if e.Key >= 0 {
+		fmt.Fprintf(&b, "at %s: %s\n", keyNames[e.Key], e.Reason)
+	} else {
+		fmt.Fprintf(&b, "%s\n", e.Reason)
+	}
+	return b.String()
+}
+
+func formatKeys(keys [7]int) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		if k == maxInt {
+			parts[i] = "-"
+		} else {
+			parts[i] = strconv.Itoa(k)
+		}
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// Explain compares a and b like Vercmp and explains the result. It returns an
+// error if a or b is not a valid semantic version.
+func Explain(a, b string) (*Explanation, error) {
+	va, err := New(a)
+	if err != nil {
+		return nil, err
+	}
+	vb, err := New(b)
+	if err != nil {
+		return nil, err
+	}
+	e := &Explanation{
+		A:      a,
+		B:      b,
+		AKeys:  va.keys(),
+		BKeys:  vb.keys(),
+		Key:    -1,
+		Reason: "all keys are equal",
+	}
+	for i := range e.AKeys {
+		if e.AKeys[i] != e.BKeys[i] {
+			e.Result = va.Compare(vb)
+			e.Key = i
+			e.Reason = reason(i, e.AKeys[i], e.BKeys[i], va, vb)
+			break
+		}
+	}
+	return e, nil
+}
+
+// reason explains why key i of a, x, differs from that of b, y.
+func reason(i, x, y int, a, b *Version) string {
+	order := "newer"
+	if x < y {
+		order = "older"
+	}
+	switch i {
+	case 3:
+		if x < y {
+			return fmt.Sprintf("dev build %s is older than %s: a dev build of a final release precedes its pre-releases", a, b)
+		}
+		return fmt.Sprintf("%s is newer than dev build %s: a dev build of a final release precedes its pre-releases", a, b)
+	case 4:
+		return fmt.Sprintf("pre-release type %s is %s than %s: types rank a < b < rc < final release",
+			typeName(a.PreReleaseType), order, typeName(b.PreReleaseType))
+	case 6:
+		if x == maxInt || y == maxInt {
+			return fmt.Sprintf("%s is %s than %s: a dev build precedes the version it leads up to", a, order, b)
+		}
+	}
+	return fmt.Sprintf("%s %d is %s than %d: numbers compare numerically", keyNames[i], x, order, y)
+}
+
+func typeName(t string) string {
+	if t == "" {
+		return "final release"
+	}
+	return t
+}
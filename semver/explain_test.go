@@ -0,0 +1,72 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		result int
+		key    int
+		reason string
+	}{
+		{"1.2.3", "2.0.0", -1, 0, "major 1 is older than 2"},
+		{"1.3.0", "1.2.9", 1, 1, "minor 3 is newer than 2"},
+		{"1.2.3", "1.2.4", -1, 2, "patch 3 is older than 4"},
+		{"1.2.3.dev1", "1.2.3.a1", -1, 3, "a dev build of a final release precedes its pre-releases"},
+		{"1.2.3.a1", "1.2.3.dev1", 1, 3, "is newer than dev build 1.2.3.dev1"},
+		{"1.2.3.a1", "1.2.3.rc1", -1, 4, "pre-release type a is older than rc"},
+		{"1.2.3", "1.2.3.rc1", 1, 4, "pre-release type final release is newer than rc"},
+		{"1.2.3.b2", "1.2.3.b1", 1, 5, "pre-release number 2 is newer than 1"},
+		{"1.2.3.rc1.dev2", "1.2.3.rc1", -1, 6, "a dev build precedes the version it leads up to"},
+		{"1.2.3.dev1", "1.2.3.dev2", -1, 6, "dev count 1 is older than 2"},
+		{"1.2.3", "1.2.3", 0, -1, "all keys are equal"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			e, err := Explain(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got := sign(e.Result); got != tt.result {
+				t.Errorf("Result: got %d, want %d", got, tt.result)
+			}
+			if got, want := sign(e.Result), sign(Vercmp(tt.a, tt.b)); got != want {
+				t.Errorf("Result: got %d, Vercmp gives %d", got, want)
+			}
+			if e.Key != tt.key {
+				t.Errorf("Key: got %d, want %d", e.Key, tt.key)
+			}
+			if !strings.Contains(e.Reason, tt.reason) {
+				t.Errorf("Reason: got %q, want it to contain %q", e.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestExplainErrors(t *testing.T) {
+	for _, pair := range [][2]string{{"1.2", "1.2.3"}, {"1.2.3", "x"}} {
+		if _, err := Explain(pair[0], pair[1]); err == nil {
+			t.Errorf("Explain(%s, %s): got nil, want error", pair[0], pair[1])
+		}
+	}
+}
+
+func TestExplanationString(t *testing.T) {
+	e, err := Explain("1.2.3.rc1.dev2", "1.2.3.rc1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `a: 1.2.3.rc1.dev2 -> [1 2 3 1 3 1 2]
+b: 1.2.3.rc1 -> [1 2 3 1 3 1 -]
+1.2.3.rc1.dev2 < 1.2.3.rc1
+at dev count: 1.2.3.rc1.dev2 is older than 1.2.3.rc1: a dev build precedes the version it leads up to
+`
+	if got := e.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/wfscheper/vercmp/internal/ordertest"
+)
+
+func FuzzNew(f *testing.F) {
+	for _, v := range versionEqualityTests {
+		f.Add(v)
+	}
+	f.Add(" 1.2.3.RC1 ")
+	f.Add("1.2.3.")
+	f.Add("1.2.3.a1.dev1.")
+	f.Fuzz(func(t *testing.T, s string) {
+		v, err := New(s)
+		if err != nil {
+			return
+		}
+		again, err := New(v.String())
+		if err != nil {
+			t.Fatalf("New(%q): got %v, want nil", v, err)
+		}
+		if *again != *v {
+			t.Errorf("New(%q): got %#v, want %#v", v, again, v)
+		}
+		key, err := FromSortKey(v.SortKey())
+		if err != nil || !key.Equal(v) {
+			t.Errorf("FromSortKey(%q): got %v, %v", v, key, err)
+		}
+	})
+}
+
+func FuzzVercmp(f *testing.F) {
+	for i, v := range versionEqualityTests[:len(versionEqualityTests)-2] {
+		f.Add(v, versionEqualityTests[i+1], versionEqualityTests[i+2])
+	}
+	f.Add("1.2.3", "1.2.3.dev-1", "1.2.3.dev1")
+	f.Fuzz(func(t *testing.T, a, b, c string) {
+		for _, s := range []string{a, b, c} {
+			if _, err := New(s); err != nil {
+				return
+			}
+		}
+		ordertest.Laws(t, func(a, b string) int { return Vercmp(a, b) }, a, b, c)
+	})
+}
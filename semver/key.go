@@ -0,0 +1,46 @@
+package semver
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var errInvalidKey = errors.New("invalid semantic version sort key")
+
+// SortKey returns a byte string whose lexicographic order matches the order of
+// Vercmp, so that versions can be ordered by stores that only compare bytes.
+// Each comparison key is written as a big-endian integer with its sign bit
+// flipped, so that negative numbers sort before positive ones.
+func (s Version) SortKey() []byte {
+	keys := s.keys()
+	b := make([]byte, 8*len(keys))
+	for i, k := range keys {
+		binary.BigEndian.PutUint64(b[8*i:], uint64(k)^(1<<63))
+	}
+	return b
+}
+
+// FromSortKey returns the Version encoded by key.
+func FromSortKey(key []byte) (*Version, error) {
+	var keys [7]int
+	if len(key) != 8*len(keys) {
+		return nil, errInvalidKey
+	}
+	for i := range keys {
+		keys[i] = int(binary.BigEndian.Uint64(key[8*i:]) ^ (1 << 63))
+	}
+
+	s := &Version{Major: keys[0], Minor: keys[1], Patch: keys[2], PreRelease: keys[5]}
+	for t, k := range typeMap {
+		if k == keys[4] {
+			s.PreReleaseType = t
+		}
+	}
+	if keys[6] != maxInt {
+		s.DevCount = keys[6]
+	}
+	if s.keys() != keys || (s.PreReleaseType == "" && s.PreRelease != 0) {
+		return nil, errInvalidKey
+	}
+	return s, nil
+}
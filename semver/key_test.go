@@ -0,0 +1,81 @@
+package semver
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSortKeyOrder(t *testing.T) {
+	corpus := append([]string{"-1.0.0", "0.0.0", "1.2.3.dev1", "1.2.3.a1.dev1"}, versionEqualityTests...)
+
+	t.Parallel()
+	for _, a := range corpus {
+		for _, b := range corpus {
+			av, _ := New(a)
+			bv, _ := New(b)
+			want := sign(Vercmp(av, bv))
+			got := bytes.Compare(av.SortKey(), bv.SortKey())
+			if got != want {
+				t.Errorf("%q vs %q: got %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestFromSortKey(t *testing.T) {
+	t.Parallel()
+	for _, v := range versionEqualityTests {
+		t.Run(v, func(t *testing.T) {
+			want, _ := New(v)
+			got, err := FromSortKey(want.SortKey())
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if *got != *want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFromSortKeyInvalid(t *testing.T) {
+	valid := Version{Major: 1, Minor: 2, Patch: 3}.SortKey()
+	tests := []struct {
+		title string
+		key   []byte
+	}{
+		{"Empty", []byte{}},
+		{"Short", valid[:len(valid)-1]},
+		{"Long", append(valid, 0)},
+		{"Wrong release key", flip(valid, 31)},
+		{"Unknown pre-release type", flip(valid, 39)},
+		{"Pre-release without type", flip(valid, 47)},
+		{"Zero dev count", append(append([]byte{}, valid[:48]...), 0x80, 0, 0, 0, 0, 0, 0, 0)},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if v, err := FromSortKey(tt.key); err == nil {
+				t.Errorf("got %v, want error", v)
+			}
+		})
+	}
+}
+
+// flip returns a copy of b with the lowest bit of b[i] flipped.
+func flip(b []byte, i int) []byte {
+	c := append([]byte{}, b...)
+	c[i] ^= 1
+	return c
+}
+
+func sign(i int) int {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	}
+	return 0
+}
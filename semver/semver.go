@@ -2,12 +2,19 @@
 //
 // Semantic versions conform to the Semantic Versioning 3.0.0 standard
 // described at http://docs.openstack.org/developer/pbr/semver.html.
+//
+// New rejects a version with a malformed pre-release or dev part, or with
+// parts after the dev part. It used to accept some of them as a different
+// version: 1.2.3.rc and 1.2.3.a as the release 1.2.3, 1.2.3.rx1 as a
+// pre-release of type rx, and 1.2.3.a1.dev1.dev2 as 1.2.3.a1.dev1.
 package semver
 
 import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/wfscheper/vercmp/cache"
 )
 
 const maxInt = int(^uint(0) >> 1)
@@ -54,6 +61,22 @@ func (s Version) String() string {
 	return str
 }
 
+// MarshalText implements encoding.TextMarshaler.
+func (s Version) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It returns an error if
+// text is not a valid semantic version.
+func (s *Version) UnmarshalText(text []byte) error {
+	v, err := New(string(text))
+	if err != nil {
+		return err
+	}
+	*s = *v
+	return nil
+}
+
 // New parses a semantic version, per Semantic Versioning 3.0.0.
 func New(v string) (*Version, error) {
 	s := new(Version)
@@ -83,8 +106,8 @@ func New(v string) (*Version, error) {
 		return nil, fmt.Errorf("Invalid patch version: %v", v)
 	}
 
-	if len(parsed) > 0 {
-		if parsed[0][0] == 'a' || parsed[0][0] == 'b' || parsed[0][0] == 'r' {
+	if len(parsed) > 0 && parsed[0] != "" {
+		if c := parsed[0][0]; c == 'a' || c == 'b' || c == 'r' {
 			// pre-release
 			part, parsed = pop(parsed)
 			if preReleaseType, preRelease, err := parsePreRelease(part); err == nil {
@@ -93,63 +116,91 @@ func New(v string) (*Version, error) {
 				return nil, fmt.Errorf("Invalid pre-release version: %v", v)
 			}
 		}
-		if part, parsed = pop(parsed); part != "" {
-			// dev part
-			if len(part) < 4 || part[:3] != "dev" {
-				return nil, fmt.Errorf("Invalid dev version: %v", v)
-			}
-			if devCount, err := strconv.Atoi(part[3:]); err == nil {
-				s.DevCount = devCount
-			} else {
-				return nil, fmt.Errorf("Invalid dev version: %v", v)
-			}
+	}
+	if len(parsed) > 0 {
+		// dev part
+		part, parsed = pop(parsed)
+		if len(part) < 4 || part[:3] != "dev" {
+			return nil, fmt.Errorf("Invalid dev version: %v", v)
+		}
+		if devCount, err := strconv.Atoi(part[3:]); err == nil {
+			s.DevCount = devCount
+		} else {
+			return nil, fmt.Errorf("Invalid dev version: %v", v)
 		}
 	}
+	if len(parsed) > 0 {
+		return nil, fmt.Errorf("Invalid semantic version: %v", v)
+	}
 	return s, nil
 }
 
+// Compare compares s with other, and returns an integer less than 0 if s is
+// older than other, 0 if they are the same, and an integer greater than 0 if s
+// is newer than other.
+func (s *Version) Compare(other *Version) int {
+	otherKeys := other.keys()
+	for idx, key := range s.keys() {
+		// Subtracting the keys could overflow, as an absent dev count is
+		// maxInt.
+		switch otherKey := otherKeys[idx]; {
+		case key < otherKey:
+			return -1
+		case key > otherKey:
+			return 1
+		}
+	}
+	return 0
+}
+
+// Equal reports whether s and other are the same version.
+func (s *Version) Equal(other *Version) bool {
+	return s.Compare(other) == 0
+}
+
+// LessThan reports whether s is older than other.
+func (s *Version) LessThan(other *Version) bool {
+	return s.Compare(other) < 0
+}
+
 // Vercmp compares two semantic versions and returns an integer less than 0
 // if a is older than b, 0 if a and b are the same, and an integer greater than
-// 0 if a is newer than b.
+// 0 if a is newer than b. a and b can be either a string or a Version. Vercmp
+// panics if a or b is of any other type, or is a string that is not a valid
+// semantic version.
 func Vercmp(a, b interface{}) int {
-	var aVer, bVer *Version
-	var err error
+	return toVersion(a).Compare(toVersion(b))
+}
 
-	switch a := a.(type) {
-	default:
-		panic(fmt.Sprintf("Unparsable type %T", a))
+var parseCache = cache.New(0)
+
+// ParseCache returns the cache of parsed versions that Vercmp uses for
+// strings. It starts disabled; Resize it to enable it. It only holds valid
+// versions.
+func ParseCache() *cache.Cache {
+	return parseCache
+}
+
+// toVersion returns v as a *Version, parsing it if it is a string.
+func toVersion(v interface{}) *Version {
+	switch v := v.(type) {
 	case string:
-		aVer, err = New(a)
-		if err != nil {
-			panic(fmt.Sprint(err))
+		if cached, ok := parseCache.Get(v); ok {
+			return cached.(*Version)
 		}
-	case Version:
-		aVer = &a
-	case *Version:
-		aVer = a
-	}
-	switch b := b.(type) {
-	default:
-		panic(fmt.Sprintf("Unparsable type %T", b))
-	case string:
-		bVer, err = New(b)
+		parsed, err := New(v)
 		if err != nil {
 			panic(fmt.Sprint(err))
 		}
-	case Version:
-		bVer = &b
+		parseCache.Add(v, parsed)
+		return parsed
 	case *Version:
-		bVer = b
-	}
-
-	bKeys := bVer.keys()
-	for idx, aKey := range aVer.keys() {
-		bKey := bKeys[idx]
-		if aKey != bKey {
-			return aKey - bKey
-		}
+		return v
+	case Version:
+		return &v
+	default:
+		panic(fmt.Sprintf("Unparsable type %T", v))
 	}
-	return 0
 }
 
 // parseBuffer converts a numeric string to an interger, otherwise it returns
@@ -166,15 +217,18 @@ func parseBuffer(b string) interface{} {
 func parsePreRelease(s string) (string, int, error) {
 	var preReleaseType string
 
-	if s[0] == 'r' {
+	if strings.HasPrefix(s, "rc") {
 		preReleaseType, s = s[:2], s[2:]
+	} else if s != "" && (s[0] == 'a' || s[0] == 'b') {
+		preReleaseType, s = s[:1], s[1:]
 	} else {
-		preReleaseType, s = string(s[0]), s[1:]
+		return "", 0, fmt.Errorf("unknown pre-release type: %v", s)
 	}
-	if preRelease, err := strconv.Atoi(s); err == nil {
-		return preReleaseType, preRelease, err
+	preRelease, err := strconv.Atoi(s)
+	if err != nil {
+		return "", 0, err
 	}
-	return "", 0, nil
+	return preReleaseType, preRelease, nil
 }
 
 // pop removes the first element from the slice, and returns it and the
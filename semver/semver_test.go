@@ -16,6 +16,7 @@
 package semver
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 )
@@ -149,6 +150,52 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewInvalid(t *testing.T) {
+	tests := []string{
+		"1.2",
+		"1.2.x",
+		"1.2.3.",
+		"1.2.3.r",
+		"1.2.3.dev",
+		"1.2.3..dev1",
+	}
+
+	t.Parallel()
+	for _, v := range tests {
+		t.Run(v, func(t *testing.T) {
+			if got, err := New(v); err == nil {
+				t.Errorf("got %v, want error", got)
+			}
+		})
+	}
+}
+
+// TestNewRejectsMalformedParts covers a change in behavior: New used to
+// accept these versions as different ones, dropping a pre-release without a
+// number, taking any word starting with r as a pre-release type, and ignoring
+// everything after the dev part. It now returns an error for each.
+func TestNewRejectsMalformedParts(t *testing.T) {
+	tests := []struct{ v, accepted string }{
+		{"1.2.3.rc", "1.2.3"},
+		{"1.2.3.a", "1.2.3"},
+		{"1.2.3.b", "1.2.3"},
+		{"1.2.3.rx1", "1.2.3.rx1"},
+		{"1.2.3.a1.", "1.2.3.a1"},
+		{"1.2.3.a1.dev1.dev2", "1.2.3.a1.dev1"},
+		{"1.2.3.dev1.x", "1.2.3.dev1"},
+		{"1.2.3.rc1.dev2.foo", "1.2.3.rc1.dev2"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.v, func(t *testing.T) {
+			if got, err := New(tt.v); err == nil {
+				t.Errorf("got %v, want error (was accepted as %s)", got, tt.accepted)
+			}
+		})
+	}
+}
+
 var versionEqualityTests = []string{
 	"1.2.3.dev6",
 	"1.2.3.dev7",
@@ -198,6 +245,139 @@ func TestVersionOrdering(t *testing.T) {
 	}
 }
 
+func TestVersionMethods(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		want  int
+		equal bool
+		less  bool
+	}{
+		{"1.2.3", "1.2.3", 0, true, false},
+		{"1.2.3.rc1", "1.2.3", -1, false, true},
+		{"1.2.4.dev1", "1.2.3", 1, false, false},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, _ := New(tt.a)
+			b, _ := New(tt.b)
+			if got := sign(a.Compare(b)); got != tt.want {
+				t.Errorf("Compare: got %d, want %d", got, tt.want)
+			}
+			if got := a.Equal(b); got != tt.equal {
+				t.Errorf("Equal: got %v, want %v", got, tt.equal)
+			}
+			if got := a.LessThan(b); got != tt.less {
+				t.Errorf("LessThan: got %v, want %v", got, tt.less)
+			}
+		})
+	}
+}
+
+func TestVercmpPanics(t *testing.T) {
+	tests := []struct {
+		title string
+		a, b  interface{}
+	}{
+		{"Unsupported type", 1, "1.2.3"},
+		{"Invalid version", "1.2.3", "1.2"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("got no panic, want panic")
+				}
+			}()
+			Vercmp(tt.a, tt.b)
+		})
+	}
+}
+
+func TestVersionText(t *testing.T) {
+	tests := []struct {
+		v, want string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"1.2.3.a4.dev5", "1.2.3.a4.dev5"},
+		{" 1.2.3.RC1 ", "1.2.3.rc1"},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.v, func(t *testing.T) {
+			v, err := New(tt.v)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			text, err := v.MarshalText()
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if string(text) != tt.want {
+				t.Errorf("got %q, want %q", text, tt.want)
+			}
+			var got Version
+			if err := got.UnmarshalText([]byte(tt.v)); err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if !reflect.DeepEqual(&got, v) {
+				t.Errorf("got %v, want %v", got, v)
+			}
+		})
+	}
+}
+
+func TestVersionUnmarshalTextError(t *testing.T) {
+	v := Version{Major: 1}
+	if err := v.UnmarshalText([]byte("1.2")); err == nil {
+		t.Error("got nil, want error")
+	}
+	if !reflect.DeepEqual(v, Version{Major: 1}) {
+		t.Errorf("got %v, want the version unchanged", v)
+	}
+}
+
+func TestVersionJSON(t *testing.T) {
+	type config struct {
+		Version Version
+		Pointer *Version
+		Dates   map[Version]string
+	}
+	data := `{"Version":"1.2.3.rc1","Pointer":"2.0.0","Dates":{"1.0.0":"2019-01-01","1.1.0.dev3":"2019-01-05"}}`
+
+	var got config
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	want := config{
+		Version{Major: 1, Minor: 2, Patch: 3, PreReleaseType: "rc", PreRelease: 1},
+		&Version{Major: 2},
+		map[Version]string{
+			Version{Major: 1}:                        "2019-01-01",
+			Version{Major: 1, Minor: 1, DevCount: 3}: "2019-01-05",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if string(b) != data {
+		t.Errorf("got %s, want %s", b, data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Version":"1.2"}`), &got); err == nil {
+		t.Error("got nil, want error")
+	}
+}
+
 func BenchmarkVercmp(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		Vercmp("1.2.3.a5.dev6", "1.2.3.a5.dev7")
@@ -281,3 +461,28 @@ func combinations(s []string, r int) [][]string {
 		rv = append(rv, c)
 	}
 }
+
+func TestParseCache(t *testing.T) {
+	c := ParseCache()
+	if got := c.Stats().Capacity; got != 0 {
+		t.Errorf("default capacity: got %d, want a disabled cache", got)
+	}
+	c.Resize(100)
+	defer c.Resize(0)
+	c.Purge()
+	defer c.Purge()
+
+	Vercmp("1.2.3", "1.2.4")
+	Vercmp("1.2.3", "1.2.4")
+	if got := c.Stats(); got.Hits != 2 || got.Misses != 2 || got.Len != 2 {
+		t.Errorf("after comparing the same strings twice: got %+v, want 2 hits, 2 misses and 2 versions", got)
+	}
+
+	func() {
+		defer func() { recover() }()
+		Vercmp("1.2.3", "1.2")
+	}()
+	if _, ok := c.Get("1.2"); ok {
+		t.Error("got an invalid version cached")
+	}
+}
@@ -0,0 +1,49 @@
+package semver
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Scan implements sql.Scanner. It accepts text columns, and returns an error
+// if the column does not hold a valid semantic version.
+func (s *Version) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		return s.UnmarshalText([]byte(src))
+	case []byte:
+		return s.UnmarshalText(src)
+	default:
+		return fmt.Errorf("cannot scan %T into a semantic version", src)
+	}
+}
+
+// Value implements driver.Valuer.
+func (s Version) Value() (driver.Value, error) {
+	return s.String(), nil
+}
+
+// Key is a Version that is stored in a database as an order-preserving key
+// rather than as text. Writing Key(*v) to a binary column alongside v lets
+// range queries and ORDER BY compare versions server-side.
+type Key Version
+
+// Value implements driver.Valuer.
+func (k Key) Value() (driver.Value, error) {
+	return Version(k).SortKey(), nil
+}
+
+// Scan implements sql.Scanner. It accepts binary columns holding a key
+// written by Value.
+func (k *Key) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into a semantic version key", src)
+	}
+	v, err := FromSortKey(b)
+	if err != nil {
+		return err
+	}
+	*k = Key(*v)
+	return nil
+}
@@ -0,0 +1,136 @@
+package semver
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/wfscheper/vercmp/internal/sqltest"
+)
+
+func TestVersionScan(t *testing.T) {
+	tests := []struct {
+		src     interface{}
+		want    *Version
+		wantErr bool
+	}{
+		{"1.2.3.rc1", &Version{Major: 1, Minor: 2, Patch: 3, PreReleaseType: "rc", PreRelease: 1}, false},
+		{[]byte("1.2.3.dev4"), &Version{Major: 1, Minor: 2, Patch: 3, DevCount: 4}, false},
+		{"1.2", nil, true},
+		{nil, nil, true},
+		{int64(1), nil, true},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("Scan(%v)", tt.src), func(t *testing.T) {
+			var got Version
+			err := got.Scan(tt.src)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("got nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if !reflect.DeepEqual(&got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionValue(t *testing.T) {
+	got, err := Version{Major: 1, Minor: 2, Patch: 3, PreReleaseType: "a", PreRelease: 4}.Value()
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if got != "1.2.3.a4" {
+		t.Errorf("got %v, want %v", got, "1.2.3.a4")
+	}
+}
+
+func TestVersionSQL(t *testing.T) {
+	db, err := sql.Open(sqltest.DriverName, t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("CREATE TABLE versions (version, key)"); err != nil {
+		t.Fatal(err)
+	}
+	for i := len(versionEqualityTests) - 1; i >= 0; i-- {
+		v, err := New(versionEqualityTests[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec("INSERT INTO versions VALUES (?, ?)", v, Key(*v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("Order by key", func(t *testing.T) {
+		got := queryVersions(t, db, "SELECT version FROM versions ORDER BY key")
+		if !reflect.DeepEqual(got, versionEqualityTests) {
+			t.Errorf("got %v, want %v", got, versionEqualityTests)
+		}
+	})
+
+	t.Run("Range of keys", func(t *testing.T) {
+		low, _ := New("1.2.3.b3")
+		high, _ := New("1.2.4")
+		got := queryVersions(t, db, "SELECT version FROM versions WHERE key >= ? AND key < ? ORDER BY key",
+			Key(*low), Key(*high))
+		want := []string{"1.2.3.b3", "1.2.3.rc2.dev1", "1.2.3.rc2", "1.2.3.rc3.dev1", "1.2.3"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Scan keys", func(t *testing.T) {
+		rows, err := db.Query("SELECT key FROM versions ORDER BY key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		var got []string
+		for rows.Next() {
+			var k Key
+			if err := rows.Scan(&k); err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, Version(k).String())
+		}
+		if len(got) != len(versionEqualityTests) {
+			t.Fatalf("got %d keys, want %d", len(got), len(versionEqualityTests))
+		}
+		for i := range got {
+			if Vercmp(got[i], versionEqualityTests[i]) != 0 {
+				t.Errorf("got %v, want %v", got[i], versionEqualityTests[i])
+			}
+		}
+	})
+}
+
+func queryVersions(t *testing.T, db *sql.DB, query string, args ...interface{}) []string {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var versions []string
+	for rows.Next() {
+		var v Version
+		if err := rows.Scan(&v); err != nil {
+			t.Fatal(err)
+		}
+		versions = append(versions, v.String())
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return versions
+}
@@ -0,0 +1,126 @@
+package semver
+
+import "sort"
+
+// Versions is a collection of parsed versions that keeps the strings they
+// were parsed from. It implements sort.Interface, ordering versions from
+// oldest to newest.
+type Versions struct {
+	versions  []*Version
+	originals []string
+}
+
+// NewVersions parses each of ss once and returns the resulting collection,
+// along with the strings that are not valid semantic versions.
+func NewVersions(ss []string) (Versions, []string) {
+	var vs Versions
+	var invalid []string
+	for _, s := range ss {
+		v, err := New(s)
+		if err != nil {
+			invalid = append(invalid, s)
+			continue
+		}
+		vs.versions = append(vs.versions, v)
+		vs.originals = append(vs.originals, s)
+	}
+	return vs, invalid
+}
+
+func (vs Versions) Len() int {
+	return len(vs.versions)
+}
+
+func (vs Versions) Less(i, j int) bool {
+	return vs.versions[i].LessThan(vs.versions[j])
+}
+
+func (vs Versions) Swap(i, j int) {
+	vs.versions[i], vs.versions[j] = vs.versions[j], vs.versions[i]
+	vs.originals[i], vs.originals[j] = vs.originals[j], vs.originals[i]
+}
+
+// At returns the i-th version of vs.
+func (vs Versions) At(i int) *Version {
+	return vs.versions[i]
+}
+
+// Strings returns the original version strings of vs.
+func (vs Versions) Strings() []string {
+	return append([]string(nil), vs.originals...)
+}
+
+// BinarySearch searches the sorted collection vs for v. It returns the index
+// of the first version that is not older than v, and whether that version is
+// equal to v.
+func (vs Versions) BinarySearch(v *Version) (int, bool) {
+	i := sort.Search(vs.Len(), func(i int) bool {
+		return vs.versions[i].Compare(v) >= 0
+	})
+	return i, i < vs.Len() && vs.versions[i].Compare(v) == 0
+}
+
+// Sort returns the valid versions in ss ordered from oldest to newest, along
+// with the strings that are not valid semantic versions. Versions are
+// returned as they appear in ss, and versions that are equal keep their
+// original order.
+func Sort(ss []string) (sorted, invalid []string) {
+	vs, invalid := NewVersions(ss)
+	sort.Stable(vs)
+	return vs.Strings(), invalid
+}
+
+// Max returns the newest valid version in ss, or "" if there is none, along
+// with the strings that are not valid semantic versions. If several versions
+// are equally new, the first is returned.
+func Max(ss []string) (max string, invalid []string) {
+	return extreme(ss, 1)
+}
+
+// Min returns the oldest valid version in ss, or "" if there is none, along
+// with the strings that are not valid semantic versions. If several versions
+// are equally old, the first is returned.
+func Min(ss []string) (min string, invalid []string) {
+	return extreme(ss, -1)
+}
+
+func extreme(ss []string, want int) (string, []string) {
+	vs, invalid := NewVersions(ss)
+	if vs.Len() == 0 {
+		return "", invalid
+	}
+	best := 0
+	for i := 1; i < vs.Len(); i++ {
+		if vs.versions[i].Compare(vs.versions[best])*want > 0 {
+			best = i
+		}
+	}
+	return vs.originals[best], invalid
+}
+
+// Dedupe returns the valid versions in ss without those that are equal to an
+// earlier version, along with the strings that are not valid semantic
+// versions. The order of ss is kept.
+func Dedupe(ss []string) (deduped, invalid []string) {
+	vs, invalid := NewVersions(ss)
+	idx := make([]int, vs.Len())
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return vs.Less(idx[i], idx[j])
+	})
+
+	keep := make([]bool, vs.Len())
+	for i, n := range idx {
+		if i == 0 || !vs.versions[idx[i-1]].Equal(vs.versions[n]) {
+			keep[n] = true
+		}
+	}
+	for i, s := range vs.originals {
+		if keep[i] {
+			deduped = append(deduped, s)
+		}
+	}
+	return deduped, invalid
+}
@@ -0,0 +1,108 @@
+package semver
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestVersionsSort(t *testing.T) {
+	vs, invalid := NewVersions([]string{"1.2.3", "1.2", "1.2.3.RC1", " 0.1.0", "1.2.3.a1.dev2"})
+	if want := []string{"1.2"}; !reflect.DeepEqual(invalid, want) {
+		t.Errorf("got invalid %v, want %v", invalid, want)
+	}
+	sort.Sort(vs)
+	want := []string{" 0.1.0", "1.2.3.a1.dev2", "1.2.3.RC1", "1.2.3"}
+	if got := vs.Strings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	// The parsed versions move with their strings.
+	for i, s := range want {
+		if v, _ := New(s); *vs.At(i) != *v {
+			t.Errorf("At(%d): got %v, want %v", i, vs.At(i), v)
+		}
+	}
+}
+
+func TestVersionsBinarySearch(t *testing.T) {
+	vs, _ := NewVersions(versionEqualityTests)
+	tests := []struct {
+		v         string
+		want      int
+		wantFound bool
+	}{
+		{"1.2.3.dev1", 0, false},
+		{"1.2.3.dev6", 0, true},
+		{"1.2.3.a4", 4, true},
+		{"1.2.3.rc1", 9, false},
+		{"1.2.3", 12, true},
+		{"3.0.0", 16, false},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.v, func(t *testing.T) {
+			v, _ := New(tt.v)
+			got, found := vs.BinarySearch(v)
+			if got != tt.want || found != tt.wantFound {
+				t.Errorf("got %v, %v, want %v, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	got, invalid := Sort([]string{"1.2.4", "1.2.3.RC1", "foo", "1.2.3", "1.2.3.rc1", "1.2.3.b2"})
+	want := []string{"1.2.3.b2", "1.2.3.RC1", "1.2.3.rc1", "1.2.3", "1.2.4"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if want := []string{"foo"}; !reflect.DeepEqual(invalid, want) {
+		t.Errorf("got invalid %v, want %v", invalid, want)
+	}
+}
+
+func TestMaxMin(t *testing.T) {
+	tests := []struct {
+		title            string
+		ss               []string
+		wantMax, wantMin string
+		wantInvalid      []string
+	}{
+		{"Empty", nil, "", "", nil},
+		{"Only invalid", []string{"1.0"}, "", "", []string{"1.0"}},
+		{"Mixed", []string{"1.2.3.rc1", "x", "1.2.3", "1.2.3.dev1"}, "1.2.3", "1.2.3.dev1", []string{"x"}},
+		{"Equal versions", []string{"1.2.3.RC1", "1.2.3.rc1"}, "1.2.3.RC1", "1.2.3.RC1", nil},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got, invalid := Max(tt.ss)
+			if got != tt.wantMax {
+				t.Errorf("Max: got %q, want %q", got, tt.wantMax)
+			}
+			if !reflect.DeepEqual(invalid, tt.wantInvalid) {
+				t.Errorf("Max: got invalid %v, want %v", invalid, tt.wantInvalid)
+			}
+			got, invalid = Min(tt.ss)
+			if got != tt.wantMin {
+				t.Errorf("Min: got %q, want %q", got, tt.wantMin)
+			}
+			if !reflect.DeepEqual(invalid, tt.wantInvalid) {
+				t.Errorf("Min: got invalid %v, want %v", invalid, tt.wantInvalid)
+			}
+		})
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	got, invalid := Dedupe([]string{"1.2.3", "1.2.3.A1", "1.2.4", "1.2.3.a1", " 1.2.3", "bad"})
+	want := []string{"1.2.3", "1.2.3.A1", "1.2.4"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if want := []string{"bad"}; !reflect.DeepEqual(invalid, want) {
+		t.Errorf("got invalid %v, want %v", invalid, want)
+	}
+}
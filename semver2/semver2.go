@@ -0,0 +1,161 @@
+// Package semver2 implements parsing and comparing versions by the rules of
+// Semantic Versioning 2.0.0, described at https://semver.org.
+//
+// This is the scheme of npm, Cargo and Go modules. The semver package
+// implements PBR's Semantic Versioning 3.0.0 instead, which has no
+// pre-release identifiers such as 1.0.0-beta.1.
+package semver2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Version represents a Semantic Versioning 2.0.0 version.
+type Version struct {
+	// Major, Minor and Patch are the version numbers, in decimal without
+	// leading zeros.
+	Major, Minor, Patch string
+	// PreRelease holds the dot separated identifiers after the "-", if any.
+	PreRelease []string
+	// Build holds the build metadata after the "+", which does not affect
+	// precedence.
+	Build string
+
+	original string
+}
+
+// New parses a version. A leading "v", as Go modules use, is ignored.
+func New(v string) (*Version, error) {
+	s := strings.TrimPrefix(v, "v")
+	version := &Version{original: v}
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s, version.Build = s[:i], s[i+1:]
+		for _, id := range strings.Split(version.Build, ".") {
+			if !isIdentifier(id) {
+				return nil, fmt.Errorf("invalid build metadata in version %q", v)
+			}
+		}
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		s, version.PreRelease = s[:i], strings.Split(s[i+1:], ".")
+		for _, id := range version.PreRelease {
+			if !isIdentifier(id) || isNumber(id) && !isCanonical(id) {
+				return nil, fmt.Errorf("invalid pre-release in version %q", v)
+			}
+		}
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid version %q: want major.minor.patch", v)
+	}
+	for _, p := range parts {
+		if !isNumber(p) || !isCanonical(p) {
+			return nil, fmt.Errorf("invalid version %q: %q is not a number", v, p)
+		}
+	}
+	version.Major, version.Minor, version.Patch = parts[0], parts[1], parts[2]
+	return version, nil
+}
+
+// String returns the version string v was parsed from.
+func (v *Version) String() string {
+	return v.original
+}
+
+// Compare compares v with other by precedence, and returns a negative integer
+// if v is older than other, 0 if they have the same precedence, or a positive
+// integer if v is newer than other.
+func (v *Version) Compare(other *Version) int {
+	for _, pair := range [3][2]string{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if r := compareNumbers(pair[0], pair[1]); r != 0 {
+			return r
+		}
+	}
+	// A pre-release is older than the release it precedes.
+	switch {
+	case len(v.PreRelease) == 0 && len(other.PreRelease) == 0:
+		return 0
+	case len(v.PreRelease) == 0:
+		return 1
+	case len(other.PreRelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.PreRelease) && i < len(other.PreRelease); i++ {
+		if r := compareIdentifiers(v.PreRelease[i], other.PreRelease[i]); r != 0 {
+			return r
+		}
+	}
+	return len(v.PreRelease) - len(other.PreRelease)
+}
+
+// Vercmp compares two version strings, a and b, and returns a negative integer
+// if a is older than b, 0 if they have the same precedence, or a positive
+// integer if a is newer than b. It returns an error if a or b is not a valid
+// version.
+func Vercmp(a, b string) (int, error) {
+	va, err := New(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := New(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+// compareIdentifiers compares two pre-release identifiers. Numeric identifiers
+// compare numerically and are older than alphanumeric ones, which compare in
+// ASCII order.
+func compareIdentifiers(a, b string) int {
+	aNum, bNum := isNumber(a), isNumber(b)
+	switch {
+	case aNum && bNum:
+		return compareNumbers(a, b)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// compareNumbers compares two decimal numbers without leading zeros, which
+// may be too large for an int.
+func compareNumbers(a, b string) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isCanonical reports whether the number s has no leading zeros.
+func isCanonical(s string) bool {
+	return s == "0" || s[0] != '0'
+}
@@ -0,0 +1,90 @@
+package semver2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		v    string
+		want Version
+	}{
+		{"1.2.3", Version{Major: "1", Minor: "2", Patch: "3"}},
+		{"v1.2.3", Version{Major: "1", Minor: "2", Patch: "3"}},
+		{"0.3.0-alpha.2", Version{Major: "0", Minor: "3", Patch: "0", PreRelease: []string{"alpha", "2"}}},
+		{"1.0.0-x-y.0+build.5", Version{Major: "1", Minor: "0", Patch: "0", PreRelease: []string{"x-y", "0"}, Build: "build.5"}},
+		{"0.0.0-20220314234659-1baeb1ce4c0b", Version{Major: "0", Minor: "0", Patch: "0", PreRelease: []string{"20220314234659-1baeb1ce4c0b"}}},
+		{"99999999999999999999.0.0", Version{Major: "99999999999999999999", Minor: "0", Patch: "0"}},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		got, err := New(tt.v)
+		if err != nil {
+			t.Errorf("New(%q): got %v, want nil", tt.v, err)
+			continue
+		}
+		tt.want.original = tt.v
+		if !reflect.DeepEqual(*got, tt.want) {
+			t.Errorf("New(%q): got %+v, want %+v", tt.v, *got, tt.want)
+		}
+		if got.String() != tt.v {
+			t.Errorf("String: got %q, want %q", got, tt.v)
+		}
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	tests := []string{
+		"", "1", "1.2", "1.2.3.4", "01.2.3", "1.02.3", "1.2.-3", "1.2.3-", "1.2.3-alpha..1",
+		"1.2.3-01", "1.2.3+", "1.2.3+build..1", "1.2.3-alpha_1", " 1.2.3", "V1.2.3", "1.2.3.rc1",
+	}
+
+	t.Parallel()
+	for _, v := range tests {
+		if _, err := New(v); err == nil {
+			t.Errorf("New(%q): got nil, want error", v)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// The precedence example of the specification, oldest first.
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "1.10.0", "2.0.0",
+	}
+
+	t.Parallel()
+	for i, a := range ordered {
+		for j, b := range ordered {
+			got, err := Vercmp(a, b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sign(got) != sign(i-j) {
+				t.Errorf("Vercmp(%s, %s): got %d, want %d", a, b, got, sign(i-j))
+			}
+		}
+	}
+
+	for _, pair := range [][2]string{{"1.0.0+a", "1.0.0+b"}, {"v1.0.0", "1.0.0"}, {"1.0.0-rc.1+x", "1.0.0-rc.1"}} {
+		if got, err := Vercmp(pair[0], pair[1]); err != nil || got != 0 {
+			t.Errorf("Vercmp(%s, %s): got %d, %v, want 0, nil", pair[0], pair[1], got, err)
+		}
+	}
+	if _, err := Vercmp("1.0.0", "1.0"); err == nil {
+		t.Error("Vercmp(1.0.0, 1.0): got nil, want error")
+	}
+}
+
+func sign(i int) int {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	}
+	return 0
+}
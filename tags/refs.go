@@ -0,0 +1,136 @@
+package tags
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const tagsPrefix = "refs/tags/"
+
+// ReadRefs returns the names of the tags in the git repository at dir, which
+// may be either a working tree, including one added by git worktree, or a git
+// directory. Tags are read from the loose refs under refs/tags and from the
+// packed-refs file, the same sources used by git for-each-ref, so no git
+// binary or network access is needed. The names are returned in lexical
+// order.
+func ReadRefs(dir string) ([]string, error) {
+	gitDir, err := findGitDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if gitDir, err = findCommonDir(gitDir); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	if err := readPackedRefs(filepath.Join(gitDir, "packed-refs"), names); err != nil {
+		return nil, err
+	}
+	root := filepath.Join(gitDir, filepath.FromSlash(tagsPrefix))
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return nil
+			}
+			return err
+		}
+		if info.Mode().IsRegular() {
+			name, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			names[filepath.ToSlash(name)] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]string, 0, len(names))
+	for name := range names {
+		refs = append(refs, name)
+	}
+	sort.Strings(refs)
+	return refs, nil
+}
+
+// findGitDir returns the git directory for dir. A .git file, as used by
+// worktrees and submodules, is followed to the directory it names.
+func findGitDir(dir string) (string, error) {
+	dotGit := filepath.Join(dir, ".git")
+	info, err := os.Stat(dotGit)
+	switch {
+	case os.IsNotExist(err):
+		if _, err := os.Stat(filepath.Join(dir, "HEAD")); err != nil {
+			return "", fmt.Errorf("%s is not a git repository", dir)
+		}
+		return dir, nil
+	case err != nil:
+		return "", err
+	case info.IsDir():
+		return dotGit, nil
+	}
+
+	b, err := os.ReadFile(dotGit)
+	if err != nil {
+		return "", err
+	}
+	line := strings.TrimSpace(string(b))
+	if !strings.HasPrefix(line, "gitdir:") {
+		return "", fmt.Errorf("%s is not a valid .git file", dotGit)
+	}
+	gitDir := strings.TrimSpace(strings.TrimPrefix(line, "gitdir:"))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(dir, gitDir)
+	}
+	return gitDir, nil
+}
+
+// findCommonDir returns the directory that holds the refs of gitDir. The git
+// directory of a worktree names it in its commondir file, and shares the refs
+// of the main repository.
+func findCommonDir(gitDir string) (string, error) {
+	b, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if os.IsNotExist(err) {
+		return gitDir, nil
+	} else if err != nil {
+		return "", err
+	}
+	commonDir := strings.TrimSpace(string(b))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(gitDir, commonDir)
+	}
+	return commonDir, nil
+}
+
+// readPackedRefs adds the tags listed in the packed-refs file at path to
+// names. A missing file is not an error.
+func readPackedRefs(path string, names map[string]bool) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// skip the header and the peeled values of annotated tags
+		if line == "" || line[0] == '#' || line[0] == '^' {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 || !strings.HasPrefix(fields[1], tagsPrefix) {
+			continue
+		}
+		names[strings.TrimPrefix(fields[1], tagsPrefix)] = true
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,115 @@
+package tags
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const packedRefs = `# pack-refs with: peeled fully-peeled sorted 
+1111111111111111111111111111111111111111 refs/heads/master
+2222222222222222222222222222222222222222 refs/tags/v1.0.0
+^3333333333333333333333333333333333333333
+4444444444444444444444444444444444444444 refs/tags/v1.1.0
+5555555555555555555555555555555555555555 refs/remotes/origin/master
+`
+
+func TestReadRefs(t *testing.T) {
+	work := t.TempDir()
+	gitDir := filepath.Join(work, ".git")
+	writeFile(t, filepath.Join(gitDir, "HEAD"), "ref: refs/heads/master\n")
+	writeFile(t, filepath.Join(gitDir, "packed-refs"), packedRefs)
+	writeFile(t, filepath.Join(gitDir, "refs", "tags", "v1.1.0"), "6666666666666666666666666666666666666666\n")
+	writeFile(t, filepath.Join(gitDir, "refs", "tags", "v2.0.0"), "7777777777777777777777777777777777777777\n")
+	writeFile(t, filepath.Join(gitDir, "refs", "tags", "app", "v0.1.0"), "8888888888888888888888888888888888888888\n")
+	writeFile(t, filepath.Join(gitDir, "refs", "heads", "v9.9.9"), "9999999999999999999999999999999999999999\n")
+	want := []string{"app/v0.1.0", "v1.0.0", "v1.1.0", "v2.0.0"}
+
+	t.Run("Working tree", func(t *testing.T) {
+		got, err := ReadRefs(work)
+		if err != nil {
+			t.Fatalf("got %v, want nil", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Git directory", func(t *testing.T) {
+		got, err := ReadRefs(gitDir)
+		if err != nil {
+			t.Fatalf("got %v, want nil", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Git file", func(t *testing.T) {
+		linked := t.TempDir()
+		writeFile(t, filepath.Join(linked, ".git"), "gitdir: "+gitDir+"\n")
+		got, err := ReadRefs(linked)
+		if err != nil {
+			t.Fatalf("got %v, want nil", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	// git worktree add gives the worktree a git directory of its own, which
+	// names the main one in its commondir file.
+	worktreeDir := filepath.Join(gitDir, "worktrees", "feature")
+	writeFile(t, filepath.Join(worktreeDir, "HEAD"), "ref: refs/heads/feature\n")
+	writeFile(t, filepath.Join(worktreeDir, "commondir"), "../..\n")
+
+	t.Run("Worktree", func(t *testing.T) {
+		worktree := t.TempDir()
+		writeFile(t, filepath.Join(worktree, ".git"), "gitdir: "+worktreeDir+"\n")
+		got, err := ReadRefs(worktree)
+		if err != nil {
+			t.Fatalf("got %v, want nil", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Worktree git directory", func(t *testing.T) {
+		got, err := ReadRefs(worktreeDir)
+		if err != nil {
+			t.Fatalf("got %v, want nil", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestReadRefsEmpty(t *testing.T) {
+	gitDir := t.TempDir()
+	writeFile(t, filepath.Join(gitDir, "HEAD"), "ref: refs/heads/master\n")
+	got, err := ReadRefs(gitDir)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("got %v, want no tags", got)
+	}
+}
+
+func TestReadRefsNotARepository(t *testing.T) {
+	if _, err := ReadRefs(t.TempDir()); err == nil {
+		t.Error("got nil, want error")
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,145 @@
+// Package tags sorts repository tags by the versions they name.
+//
+// Tags frequently carry a prefix, such as "v1.2.3" or "release-1.2.3". A
+// Sorter strips any of its configured prefixes from each tag, parses what is
+// left with the chosen version scheme, and orders the tags newest first. Tags
+// that do not name a version are reported separately.
+package tags
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/wfscheper/vercmp/maven"
+	"github.com/wfscheper/vercmp/semver"
+)
+
+// Scheme selects how tag versions are parsed and compared.
+type Scheme int
+
+// Supported version schemes.
+const (
+	SemVer Scheme = iota
+	Maven
+)
+
+// DefaultPrefixes are the prefixes stripped by a Sorter with no Prefixes.
+var DefaultPrefixes = []string{"v", "release-"}
+
+// Tag is a repository tag that names a version.
+type Tag struct {
+	// Name is the full name of the tag.
+	Name string
+	// Version is the name of the tag with its prefix removed.
+	Version string
+
+	parsed interface{}
+}
+
+func (t Tag) String() string {
+	return t.Name
+}
+
+// Sorter parses and sorts tags using a single version scheme.
+type Sorter struct {
+	Scheme Scheme
+	// Prefixes are stripped from tag names before parsing. The longest
+	// matching prefix wins. If Prefixes is nil, DefaultPrefixes is used.
+	Prefixes []string
+}
+
+// Parse returns the Tag for name, or an error if name does not name a version
+// in the Sorter's scheme.
+func (s Sorter) Parse(name string) (Tag, error) {
+	v := s.strip(name)
+	if v == "" || v[0] < '0' || v[0] > '9' {
+		return Tag{}, fmt.Errorf("tag %q does not name a version", name)
+	}
+	t := Tag{Name: name, Version: v}
+	switch s.Scheme {
+	case SemVer:
+		parsed, err := semver.New(v)
+		if err != nil {
+			return Tag{}, fmt.Errorf("tag %q does not name a version: %v", name, err)
+		}
+		t.parsed = parsed
+	case Maven:
+		t.parsed = maven.New(v)
+	default:
+		return Tag{}, fmt.Errorf("unknown scheme %d", s.Scheme)
+	}
+	return t, nil
+}
+
+// Sort parses names and returns the tags that name versions, newest first,
+// along with the names that do not. Tags naming equal versions are ordered by
+// name.
+func (s Sorter) Sort(names []string) (tags []Tag, invalid []string) {
+	for _, name := range names {
+		t, err := s.Parse(name)
+		if err != nil {
+			invalid = append(invalid, name)
+			continue
+		}
+		tags = append(tags, t)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if c := s.Compare(tags[i], tags[j]); c != 0 {
+			return c > 0
+		}
+		return tags[i].Name < tags[j].Name
+	})
+	return tags, invalid
+}
+
+// Compare compares the versions named by tags a and b, and returns a negative
+// integer if a is older than b, 0 if they are the same, and a positive
+// integer if a is newer than b. A tag that was not returned by Parse for the
+// Sorter's scheme, such as the zero Tag, is older than any version, and two
+// such tags are ordered by name.
+func (s Sorter) Compare(a, b Tag) int {
+	av, bv := s.version(a), s.version(b)
+	switch {
+	case av == nil && bv == nil:
+		return strings.Compare(a.Name, b.Name)
+	case av == nil:
+		return -1
+	case bv == nil:
+		return 1
+	}
+	if s.Scheme == Maven {
+		return maven.Vercmp(av, bv)
+	}
+	return semver.Vercmp(av, bv)
+}
+
+// version returns the parsed version of t, or nil if t was not parsed with
+// the Sorter's scheme.
+func (s Sorter) version(t Tag) interface{} {
+	switch v := t.parsed.(type) {
+	case *maven.Version:
+		if s.Scheme == Maven && v != nil {
+			return v
+		}
+	case *semver.Version:
+		if s.Scheme == SemVer && v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func (s Sorter) strip(name string) string {
+	prefixes := s.Prefixes
+	if prefixes == nil {
+		prefixes = DefaultPrefixes
+	}
+	longest := ""
+	for _, p := range prefixes {
+		if len(p) > len(longest) && strings.HasPrefix(name, p) {
+			longest = p
+		}
+	}
+	return name[len(longest):]
+}
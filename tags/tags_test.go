@@ -0,0 +1,153 @@
+package tags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSorterSort(t *testing.T) {
+	tests := []struct {
+		title       string
+		sorter      Sorter
+		names       []string
+		want        []string
+		wantInvalid []string
+	}{
+		{
+			"SemVer default prefixes",
+			Sorter{Scheme: SemVer},
+			[]string{"v1.2.3", "release-1.10.0", "1.2.3.rc1", "latest", "v1.2.4.dev2", "v2.0"},
+			[]string{"release-1.10.0", "v1.2.4.dev2", "v1.2.3", "1.2.3.rc1"},
+			[]string{"latest", "v2.0"},
+		},
+		{
+			"SemVer custom prefixes",
+			Sorter{Scheme: SemVer, Prefixes: []string{"app-", "app-v"}},
+			[]string{"app-1.0.0", "app-v1.1.0", "v1.2.0", "lib-1.3.0"},
+			[]string{"app-v1.1.0", "app-1.0.0"},
+			[]string{"v1.2.0", "lib-1.3.0"},
+		},
+		{
+			"SemVer no prefixes",
+			Sorter{Scheme: SemVer, Prefixes: []string{}},
+			[]string{"v1.0.0", "1.0.0"},
+			[]string{"1.0.0"},
+			[]string{"v1.0.0"},
+		},
+		{
+			"Maven",
+			Sorter{Scheme: Maven},
+			[]string{"v1.0", "v1.0-SNAPSHOT", "release-1.0.1", "v1.0-rc1", "nightly", "v1.10"},
+			[]string{"v1.10", "release-1.0.1", "v1.0", "v1.0-SNAPSHOT", "v1.0-rc1"},
+			[]string{"nightly"},
+		},
+		{
+			"Equal versions are ordered by name",
+			Sorter{Scheme: Maven},
+			[]string{"v1.0.0", "1.0", "v1"},
+			[]string{"1.0", "v1", "v1.0.0"},
+			nil,
+		},
+		{
+			"No tags",
+			Sorter{},
+			nil,
+			nil,
+			nil,
+		},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			tags, invalid := tt.sorter.Sort(tt.names)
+			var got []string
+			for _, tag := range tags {
+				got = append(got, tag.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(invalid, tt.wantInvalid) {
+				t.Errorf("got invalid %v, want %v", invalid, tt.wantInvalid)
+			}
+		})
+	}
+}
+
+func TestSorterParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		scheme  Scheme
+		want    string
+		wantErr bool
+	}{
+		{"v1.2.3", SemVer, "1.2.3", false},
+		{"release-1.2.3", SemVer, "1.2.3", false},
+		{"1.2.3.rc1", SemVer, "1.2.3.rc1", false},
+		{"vnext", SemVer, "", true},
+		{"v", SemVer, "", true},
+		{"1.2", SemVer, "", true},
+		{"v1.2", Maven, "1.2", false},
+		{"release-", Maven, "", true},
+		{"v1.2", Scheme(42), "", true},
+	}
+
+	t.Parallel()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Sorter{Scheme: tt.scheme}.Parse(tt.name)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("got nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if got.Version != tt.want {
+				t.Errorf("got %v, want %v", got.Version, tt.want)
+			}
+		})
+	}
+}
+
+func TestSorterCompareUnparsed(t *testing.T) {
+	for _, s := range []Sorter{{Scheme: SemVer}, {Scheme: Maven}} {
+		parsed, err := s.Parse("v1.0.0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		other := Sorter{Scheme: 1 - s.Scheme}
+		foreign, err := other.Parse("v2.0.0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		tests := []struct {
+			a, b Tag
+			want int
+		}{
+			{Tag{}, Tag{}, 0},
+			{Tag{}, parsed, -1},
+			{parsed, Tag{}, 1},
+			{Tag{Name: "a", Version: "2.0.0"}, Tag{Name: "b", Version: "1.0.0"}, -1},
+			{foreign, parsed, -1},
+		}
+		for _, tt := range tests {
+			if got := sign(s.Compare(tt.a, tt.b)); got != tt.want {
+				t.Errorf("scheme %d: Compare(%q, %q): got %d, want %d", s.Scheme, tt.a, tt.b, got, tt.want)
+			}
+		}
+	}
+}
+
+func sign(i int) int {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	}
+	return 0
+}